@@ -0,0 +1,409 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/skilzy/skilzy-cli/scaffold"
+	"github.com/spf13/cobra"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Check bundled scripts against the permissions declared in skill.json",
+	Long: `Scans the Python and shell scripts in the skill for network calls, URLs,
+subprocess use and filesystem writes, and reports anything the manifest's
+'permissions' do not cover.
+
+Network calls need 'permissions.network', and the host of every URL they are
+given must be in 'allowedHosts'. Other URL literals are reported as warnings,
+and Python docstrings are not scanned. Quoted strings and comments in shell scripts
+are not mistaken for commands or redirections. Filesystem writes need 'permissions.filesystem' with write
+or readWrite access, and literal paths must be relative and inside the declared
+'paths'. Subprocess use cannot be declared, so it is reported as a warning for
+reviewers.
+
+The same checks run as part of 'skilzy validate'.`,
+	Args: cobra.NoArgs,
+	Run:  runAudit,
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+}
+
+func runAudit(cmd *cobra.Command, args []string) {
+	skillDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("✗ Error getting current directory: %v\n", err)
+		os.Exit(1)
+	}
+	manifestContent, err := os.ReadFile(filepath.Join(skillDir, "skill.json"))
+	if err != nil {
+		fmt.Println("✗ skill.json not found in the current directory.")
+		os.Exit(1)
+	}
+
+	fmt.Println("🔎 Auditing scripts against declared permissions...")
+	findings, scanned, err := auditSkill(skillDir, manifestContent)
+	if err != nil {
+		fmt.Printf("✗ Audit failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	errorCount := 0
+	for _, f := range findings {
+		icon := "⚠️ "
+		if f.Severity == "error" {
+			icon = "❌"
+			errorCount++
+		}
+		fmt.Printf("%s %s\n", icon, f)
+	}
+
+	fmt.Printf("\nScanned %d script(s): %d issue(s), %d warning(s).\n", scanned, errorCount, len(findings)-errorCount)
+	if errorCount > 0 {
+		fmt.Println("✗ Declare the missing permissions in skill.json or remove the code.")
+		os.Exit(1)
+	}
+	fmt.Println("✨ All script behaviour is covered by the declared permissions.")
+}
+
+// auditFinding is a script behaviour found by the audit.
+type auditFinding struct {
+	File     string
+	Line     int
+	Severity string // "error" or "warning"
+	Message  string
+}
+
+func (f auditFinding) String() string {
+	return fmt.Sprintf("%s:%d: %s", f.File, f.Line, f.Message)
+}
+
+// auditRule matches one kind of behaviour in a line of a script. Rules for
+// shell scripts match the line with quoted strings and comments masked, so
+// that "a -> b" in an echo is not taken for a redirection.
+type auditRule struct {
+	kind     string // "network", "subprocess" or "write"
+	pattern  *regexp.Regexp
+	unquoted bool
+}
+
+var pythonAuditRules = []auditRule{
+	{"network", regexp.MustCompile(`\b(?:requests|httpx)\.(?:get|post|put|patch|delete|head|options|request|Session|Client|AsyncClient)\b`), false},
+	{"network", regexp.MustCompile(`\baiohttp\.(?:ClientSession|request)\b|\burlopen\(|\burllib3\.|\bhttp\.client\.HTTPS?Connection\b|\bsocket\.(?:socket|create_connection)\b|\b(?:ftplib|smtplib)\.`), false},
+	{"subprocess", regexp.MustCompile(`\bsubprocess\.(?:run|call|check_call|check_output|Popen|getoutput|getstatusoutput)\b|\bos\.(?:system|popen|exec\w*|spawn\w*)\(`), false},
+	{"write", regexp.MustCompile(`\bopen\([^)]*,\s*(?:mode\s*=\s*)?['"][rbt]*[wax+]`), false},
+	{"write", regexp.MustCompile(`\.(?:write_text|write_bytes|mkdir|touch|unlink|rmdir)\(|\bos\.(?:remove|unlink|rmdir|removedirs|makedirs|mkdir|rename|replace|chmod|chown|truncate)\(|\bshutil\.(?:copy\w*|move|rmtree|make_archive)\(`), false},
+}
+
+var shellAuditRules = []auditRule{
+	{"network", regexp.MustCompile(`(?:^|[;&|(\s])(?:curl|wget|nc|ncat|ssh|scp|sftp|rsync|ftp|telnet)\s`), true},
+	{"write", regexp.MustCompile(`(?:^|[^0-9&<>])>>?\s*[^&\s>]|(?:^|[;&|(\s])(?:rm|mv|cp|mkdir|touch|chmod|chown|tee|dd|ln|truncate)\s`), true},
+}
+
+var (
+	urlPattern            = regexp.MustCompile(`\b(?:https?|wss?|ftp)://([^/\s'"\x60)<>?#]+)`)
+	stringLiteralPattern  = regexp.MustCompile(`['"]([^'"\s]+)['"]`)
+	redirectTargetPattern = regexp.MustCompile(`>>?\s*(["']?)([^\s;&|"']+)`)
+)
+
+// auditSkill scans the scripts in skillDir and returns the behaviour that the
+// manifest's permissions do not cover, along with the number of scripts scanned.
+func auditSkill(skillDir string, manifestContent []byte) ([]auditFinding, int, error) {
+	var data struct {
+		Permissions scaffold.Permissions `json:"permissions"`
+	}
+	json.Unmarshal(manifestContent, &data)
+
+	var findings []auditFinding
+	scanned := 0
+	err := filepath.Walk(skillDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != skillDir && isIgnoredAuditDir(info.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rules := scriptAuditRules(path)
+		if rules == nil {
+			return nil
+		}
+		relPath, _ := filepath.Rel(skillDir, path)
+		fileFindings, err := auditScript(path, filepath.ToSlash(relPath), rules, data.Permissions)
+		if err != nil {
+			return err
+		}
+		scanned++
+		findings = append(findings, fileFindings...)
+		return nil
+	})
+	return findings, scanned, err
+}
+
+// isIgnoredAuditDir reports whether a directory holds tooling rather than skill code.
+func isIgnoredAuditDir(name string) bool {
+	switch name {
+	case "__pycache__", "node_modules", "venv", "site-packages":
+		return true
+	}
+	return strings.HasPrefix(name, ".")
+}
+
+// scriptAuditRules returns the rules for a script, or nil if path is not a script.
+func scriptAuditRules(path string) []auditRule {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".py":
+		return pythonAuditRules
+	case ".sh", ".bash":
+		return shellAuditRules
+	}
+	return nil
+}
+
+// auditScript checks one script line by line.
+func auditScript(path, relPath string, rules []auditRule, permissions scaffold.Permissions) ([]auditFinding, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var findings []auditFinding
+	reported := map[string]bool{}
+	add := func(line int, severity, message string) {
+		key := fmt.Sprintf("%d:%s", line, message)
+		if !reported[key] {
+			reported[key] = true
+			findings = append(findings, auditFinding{relPath, line, severity, message})
+		}
+	}
+
+	python := strings.ToLower(filepath.Ext(path)) == ".py"
+	docstring := ""
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if python {
+			// Docstrings and other triple-quoted blocks are prose, not code
+			line, docstring = stripTripleQuoted(line, docstring)
+			line = strings.TrimSpace(line)
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		masked := maskShellQuotes(line)
+		networkCall := false
+		for _, rule := range rules {
+			text := line
+			if rule.unquoted {
+				text = masked
+			}
+			loc := rule.pattern.FindStringIndex(text)
+			if loc == nil {
+				continue
+			}
+			call := describeAuditMatch(text[loc[0]:loc[1]])
+			switch rule.kind {
+			case "network":
+				networkCall = true
+				if permissions.Network == nil {
+					add(lineNum, "error", fmt.Sprintf("network access (%s) but skill.json declares no permissions.network", call))
+				}
+			case "subprocess":
+				add(lineNum, "warning", fmt.Sprintf("runs a subprocess (%s); reviewers will check what it executes", call))
+			case "write":
+				target := line
+				if rule.unquoted {
+					// Masking keeps offsets, so the command starts at the match
+					target = line[loc[0]:]
+				}
+				if message := uncoveredWrite(target, call, permissions.Filesystem); message != "" {
+					add(lineNum, "error", message)
+				}
+			}
+		}
+
+		// A URL passed to a recognised network call must be allowed. Any
+		// other URL literal may be a link in a help string, or may reach the
+		// network through code the rules do not know, so it is a warning
+		severity, verb := "warning", "mentions"
+		if networkCall {
+			severity, verb = "error", "contacts"
+		}
+		for _, m := range urlPattern.FindAllStringSubmatch(line, -1) {
+			switch {
+			case permissions.Network == nil:
+				add(lineNum, severity, fmt.Sprintf("%s '%s' but skill.json declares no permissions.network", verb, m[1]))
+			case !hostAllowed(m[1], permissions.Network):
+				add(lineNum, severity, fmt.Sprintf("%s '%s', which is not in permissions.network.allowedHosts", verb, m[1]))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", relPath, err)
+	}
+
+	return findings, nil
+}
+
+// stripTripleQuoted removes the contents of Python triple-quoted strings from
+// line. open is the delimiter of a string left open by an earlier line, or
+// empty; the delimiter still open at the end of line is returned with it.
+func stripTripleQuoted(line, open string) (string, string) {
+	var b strings.Builder
+	for {
+		if open != "" {
+			end := strings.Index(line, open)
+			if end < 0 {
+				return b.String(), open
+			}
+			line, open = line[end+3:], ""
+			continue
+		}
+		start := strings.Index(line, `"""`)
+		if i := strings.Index(line, "'''"); i >= 0 && (start < 0 || i < start) {
+			start = i
+		}
+		if start < 0 {
+			b.WriteString(line)
+			return b.String(), ""
+		}
+		b.WriteString(line[:start])
+		open, line = line[start:start+3], line[start+3:]
+	}
+}
+
+// maskShellQuotes replaces the contents of quoted strings in a shell line with
+// "x" and drops a trailing comment, keeping the offsets of everything else.
+func maskShellQuotes(line string) string {
+	b := []byte(line)
+	var quote byte
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' && i+1 < len(b) {
+				b[i], b[i+1] = 'x', 'x'
+				i++
+			} else {
+				b[i] = 'x'
+			}
+		case c == '\\':
+			i++
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '#' && (i == 0 || b[i-1] == ' ' || b[i-1] == '\t'):
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
+
+// describeAuditMatch turns a rule match into a short name for messages, e.g.
+// "requests.get", "open" or "output redirection".
+func describeAuditMatch(match string) string {
+	if strings.Contains(match, ">") {
+		return "output redirection"
+	}
+	call, _, _ := strings.Cut(match, "(")
+	return strings.Trim(call, ";&|( \t.")
+}
+
+// uncoveredWrite explains why a filesystem write on line is not covered by the
+// filesystem permission, or returns an empty string if it is.
+func uncoveredWrite(line, call string, fs *scaffold.FilesystemPermission) string {
+	target := ""
+	if m := redirectTargetPattern.FindStringSubmatch(line); m != nil {
+		target = m[2]
+	} else if m := stringLiteralPattern.FindStringSubmatch(line); m != nil {
+		target = m[1]
+	} else if fields := strings.Fields(line); len(fields) > 1 {
+		// Shell commands such as rm and cp write to their last argument
+		target = strings.Trim(fields[len(fields)-1], `"';`)
+	}
+	if strings.HasPrefix(target, "/dev/") {
+		// Discarding output is not a write
+		return ""
+	}
+
+	if fs == nil || (fs.Access != scaffold.FilesystemAccessWrite && fs.Access != scaffold.FilesystemAccessReadWrite) {
+		return fmt.Sprintf("writes to the filesystem (%s) but skill.json does not declare permissions.filesystem with write access", call)
+	}
+	if target == "" || strings.HasPrefix(target, "$") {
+		return ""
+	}
+	if strings.HasPrefix(target, "/") || strings.HasPrefix(target, "~") {
+		return fmt.Sprintf("writes to '%s', outside the skill directory", target)
+	}
+	if len(fs.Paths) > 0 && looksLikePath(target) && !pathDeclared(target, fs.Paths) {
+		return fmt.Sprintf("writes to '%s', which is not in permissions.filesystem.paths", target)
+	}
+	return ""
+}
+
+// looksLikePath reports whether a string literal is plausibly a file path
+// rather than, say, an open mode or an encoding name.
+func looksLikePath(s string) bool {
+	return strings.ContainsAny(s, "/.") && !strings.Contains(s, "://")
+}
+
+// pathDeclared reports whether target is one of the declared paths or inside one.
+func pathDeclared(target string, paths []string) bool {
+	clean := filepath.ToSlash(filepath.Clean(target))
+	for _, declared := range paths {
+		d := strings.TrimSuffix(filepath.ToSlash(filepath.Clean(declared)), "/")
+		if d == "." || clean == d || strings.HasPrefix(clean, d+"/") {
+			return true
+		}
+		if ok, _ := filepath.Match(d, clean); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hostAllowed reports whether host matches one of the allowed host patterns.
+// "*.example.com" matches any subdomain of example.com. A port in the pattern
+// must match; a pattern without a port allows any port.
+func hostAllowed(host string, network *scaffold.NetworkPermission) bool {
+	if network == nil {
+		return false
+	}
+	host = strings.ToLower(host[strings.LastIndex(host, "@")+1:])
+	hostName, hostPort := host, ""
+	if h, p, err := net.SplitHostPort(host); err == nil {
+		hostName, hostPort = h, p
+	}
+
+	for _, pattern := range network.AllowedHosts {
+		name, port := strings.ToLower(pattern), ""
+		if h, p, err := net.SplitHostPort(name); err == nil {
+			name, port = h, p
+		}
+		if port != "" && port != hostPort {
+			continue
+		}
+		if name == hostName {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(name, "*"); ok && strings.HasSuffix(hostName, suffix) {
+			return true
+		}
+	}
+	return false
+}
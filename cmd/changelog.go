@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/skilzy/skilzy-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	changelogVersion string
+	changelogSince   string
+	changelogStdout  bool
+)
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Generate CHANGELOG.md entries from git history",
+	Long: `Collects the commits that touched the skill directory since the last version
+tag (<name>@<version>), groups them by Conventional Commit type and writes them
+to CHANGELOG.md under the current version.
+
+The section for the current version is included in packages as RELEASE_NOTES.md
+so the registry can display it.
+
+Examples:
+  skilzy changelog
+  skilzy changelog --stdout
+  skilzy changelog --since my-skill@1.0.0 --version 1.1.0`,
+	Args: cobra.NoArgs,
+	Run:  runChangelog,
+}
+
+func init() {
+	rootCmd.AddCommand(changelogCmd)
+	changelogCmd.Flags().StringVar(&changelogVersion, "version", "", "Version to write the entries under (defaults to the version in skill.json)")
+	changelogCmd.Flags().StringVar(&changelogSince, "since", "", "Git ref to collect commits from (defaults to the last version tag)")
+	changelogCmd.Flags().BoolVar(&changelogStdout, "stdout", false, "Print the generated section instead of writing CHANGELOG.md")
+}
+
+func runChangelog(cmd *cobra.Command, args []string) {
+	skillDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("✗ Error getting current directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	data, err := readManifestInfo(skillDir)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		os.Exit(1)
+	}
+	version := changelogVersion
+	if version == "" {
+		version = data.Version
+	}
+
+	if !utils.IsGitRepo(skillDir) {
+		fmt.Printf("✗ %s is not inside a git repository\n", skillDir)
+		os.Exit(1)
+	}
+
+	since := changelogSince
+	if since == "" {
+		since = utils.LatestGitTag(skillDir, data.Name+"@*")
+	}
+	notes, count, err := generateReleaseNotes(skillDir, since)
+	if err != nil {
+		fmt.Printf("✗ Failed to read git history: %v\n", err)
+		os.Exit(1)
+	}
+
+	if changelogStdout {
+		fmt.Print(notes)
+		return
+	}
+
+	if since != "" {
+		fmt.Printf("📝 Found %d commit(s) since %s\n", count, since)
+	} else {
+		fmt.Printf("📝 Found %d commit(s)\n", count)
+	}
+	if err := upsertChangelogSection(filepath.Join(skillDir, "CHANGELOG.md"), version, time.Now(), notes); err != nil {
+		fmt.Printf("✗ %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Updated CHANGELOG.md for version %s\n", version)
+}
+
+// changelogGroups maps Conventional Commit types to changelog headings, in display order.
+// Types not listed here (chore, test, ci, build, style) are left out of the changelog.
+var changelogGroups = []struct {
+	Type    string
+	Heading string
+}{
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance"},
+	{"refactor", "Refactoring"},
+	{"docs", "Documentation"},
+	{"", "Other Changes"},
+}
+
+var conventionalCommitPattern = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// generateReleaseNotes renders the commits touching skillDir since the given ref
+// as markdown grouped by commit type, and returns the number of commits read.
+func generateReleaseNotes(skillDir, since string) (string, int, error) {
+	commits, err := utils.GitLog(skillDir, since, ".")
+	if err != nil {
+		return "", 0, err
+	}
+
+	grouped := map[string][]string{}
+	var breaking []string
+	for _, commit := range commits {
+		commitType, entry := "", commit.Subject
+		isBreaking := strings.Contains(commit.Body, "BREAKING CHANGE")
+		if m := conventionalCommitPattern.FindStringSubmatch(commit.Subject); m != nil {
+			commitType, entry = strings.ToLower(m[1]), m[4]
+			if m[2] != "" {
+				entry = fmt.Sprintf("**%s:** %s", m[2], entry)
+			}
+			isBreaking = isBreaking || m[3] == "!"
+		}
+		entry = fmt.Sprintf("- %s (%s)", entry, shortHash(commit.Hash))
+
+		if isBreaking {
+			breaking = append(breaking, entry)
+		}
+		if !isKnownChangelogType(commitType) {
+			switch commitType {
+			case "chore", "test", "ci", "build", "style":
+				continue
+			}
+			commitType = ""
+		}
+		grouped[commitType] = append(grouped[commitType], entry)
+	}
+
+	var b strings.Builder
+	if len(breaking) > 0 {
+		b.WriteString("### ⚠ BREAKING CHANGES\n\n")
+		b.WriteString(strings.Join(breaking, "\n"))
+		b.WriteString("\n\n")
+	}
+	for _, group := range changelogGroups {
+		entries := grouped[group.Type]
+		if len(entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "### %s\n\n%s\n\n", group.Heading, strings.Join(entries, "\n"))
+	}
+	return b.String(), len(commits), nil
+}
+
+func isKnownChangelogType(commitType string) bool {
+	for _, group := range changelogGroups {
+		if group.Type != "" && group.Type == commitType {
+			return true
+		}
+	}
+	return false
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+var (
+	changelogUnreleasedPattern = regexp.MustCompile(`(?mi)^## \[?Unreleased\]?[^\n]*\n?`)
+	changelogSectionPattern    = regexp.MustCompile(`(?m)^## `)
+)
+
+// changelogHeadingPattern matches the "## [version] - date" heading line of a version.
+func changelogHeadingPattern(version string) *regexp.Regexp {
+	return regexp.MustCompile(`(?m)^## \[?` + regexp.QuoteMeta(version) + `(?:\]|[ \t]|$)[^\n]*\n?`)
+}
+
+// upsertChangelogSection writes body under a "## [version] - date" heading in a
+// Keep a Changelog style file. An existing section for the version has its body
+// replaced; otherwise a new section is added below "## [Unreleased]" (taking over
+// its entries) or above the most recent release.
+func upsertChangelogSection(path, version string, date time.Time, body string) error {
+	text, err := changelogWithSection(path, version, date, body)
+	if err != nil {
+		return err
+	}
+	return writeChangelog(path, text)
+}
+
+// changelogWithSection returns the content upsertChangelogSection would write,
+// without writing it.
+func changelogWithSection(path, version string, date time.Time, body string) (string, error) {
+	heading := fmt.Sprintf("## [%s] - %s\n\n", version, date.Format("2006-01-02"))
+	body = strings.TrimSpace(body)
+	if body != "" {
+		body += "\n\n"
+	}
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		content = []byte("# Changelog\n\nAll notable changes to this skill will be documented in this file.\n\n")
+	} else if err != nil {
+		return "", fmt.Errorf("failed to read CHANGELOG.md: %w", err)
+	}
+	text := string(content)
+
+	if loc := changelogHeadingPattern(version).FindStringIndex(text); loc != nil {
+		// Replace the body of the existing section, keeping its heading
+		rest := text[loc[1]:]
+		end := len(rest)
+		if next := changelogSectionPattern.FindStringIndex(rest); next != nil {
+			end = next[0]
+		}
+		if body != "" {
+			text = text[:loc[1]] + "\n" + body + rest[end:]
+		}
+	} else if loc := changelogUnreleasedPattern.FindStringIndex(text); loc != nil {
+		text = text[:loc[0]] + "## [Unreleased]\n\n" + heading + body + strings.TrimLeft(text[loc[1]:], "\n")
+	} else if loc := changelogSectionPattern.FindStringIndex(text); loc != nil {
+		text = text[:loc[0]] + heading + body + text[loc[0]:]
+	} else {
+		text = strings.TrimRight(text, "\n") + "\n\n" + heading + body
+	}
+
+	return strings.TrimRight(text, "\n") + "\n", nil
+}
+
+func writeChangelog(path, text string) error {
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		return fmt.Errorf("failed to write CHANGELOG.md: %w", err)
+	}
+	return nil
+}
+
+// currentReleaseNotes returns the body of the CHANGELOG.md section for version,
+// or an empty string if the skill has no changelog entry for it.
+func currentReleaseNotes(skillDir, version string) string {
+	content, err := os.ReadFile(filepath.Join(skillDir, "CHANGELOG.md"))
+	if err != nil {
+		return ""
+	}
+	text := string(content)
+
+	loc := changelogHeadingPattern(version).FindStringIndex(text)
+	if loc == nil {
+		return ""
+	}
+	rest := text[loc[1]:]
+	if next := changelogSectionPattern.FindStringIndex(rest); next != nil {
+		rest = rest[:next[0]]
+	}
+	return strings.TrimSpace(rest)
+}
@@ -10,6 +10,8 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/skilzy/skilzy-cli/icon"
+	"github.com/skilzy/skilzy-cli/license"
 	"github.com/skilzy/skilzy-cli/scaffold"
 	"github.com/skilzy/skilzy-cli/utils"
 	"github.com/spf13/cobra"
@@ -60,7 +62,7 @@ func runConvert(cmd *cobra.Command, args []string) {
 	fmt.Printf("   Description: %s\n", frontMatter.Description)
 
 	finalSkillData := scaffold.SkillData{}
-	if err := runConversionSurvey(&finalSkillData, *frontMatter); err != nil {
+	if err := runConversionSurvey(&finalSkillData, *frontMatter, sourceSkillDir); err != nil {
 		fmt.Printf("✗ Aborted. %v\n", err)
 		os.Exit(1)
 	}
@@ -74,7 +76,7 @@ func runConvert(cmd *cobra.Command, args []string) {
 	fmt.Println("   Next, run 'cd '" + finalSkillData.Name + "' && skilzy validate' to confirm.")
 }
 
-func runConversionSurvey(data *scaffold.SkillData, fm FrontMatter) error {
+func runConversionSurvey(data *scaffold.SkillData, fm FrontMatter, sourceDir string) error {
 	defaultAuthor := utils.GetGitUserName()
 	answers := struct {
 		Name, Description, Author, License, RepositoryURL, Keywords string
@@ -85,7 +87,7 @@ func runConversionSurvey(data *scaffold.SkillData, fm FrontMatter) error {
 		{Name: "name", Prompt: &survey.Input{Message: "Skill Name:", Default: fm.Name}, Validate: func(val interface{}) error { return validateSkillName(val.(string)) }},
 		{Name: "description", Prompt: &survey.Input{Message: "Description:", Default: fm.Description}, Validate: survey.MinLength(20)},
 		{Name: "author", Prompt: &survey.Input{Message: "Author:", Default: defaultAuthor}, Validate: survey.Required},
-		{Name: "license", Prompt: &survey.Select{Message: "License:", Options: []string{"MIT", "Apache-2.0", "GPL-3.0"}, Default: "MIT"}},
+		{Name: "license", Prompt: &survey.Select{Message: "License:", Options: license.Common(), Default: "MIT"}},
 	}
 
 	fmt.Println("\nThis utility will convert the skill to the Skilzy format.")
@@ -97,18 +99,28 @@ func runConversionSurvey(data *scaffold.SkillData, fm FrontMatter) error {
 	survey.AskOne(&survey.Input{Message: "GitHub Repository URL (optional):"}, &answers.RepositoryURL)
 	survey.AskOne(&survey.Input{Message: "Keywords (comma-separated, optional):"}, &answers.Keywords)
 
+	runtime, err := askRuntime(scaffold.Runtime{Type: detectRuntime(sourceDir)})
+	if err != nil {
+		return err
+	}
+	data.Runtime = runtime
+
+	permissions, err := askPermissions()
+	if err != nil {
+		return err
+	}
+	data.Permissions = permissions
+
 	data.Name = answers.Name
 	data.Description = answers.Description
 	data.Author = answers.Author
 	data.License = answers.License
 	data.Version = "1.0.0"
 	data.Entrypoint = "README.md"
-	data.Icon = "assets/icon.svg"
+	data.Icon = defaultIconPath
 	if answers.RepositoryURL != "" {
 		data.Repository = &scaffold.Repository{Type: "git", URL: answers.RepositoryURL}
 	}
-	data.Runtime.Type = "python"
-	data.Runtime.Version = ">=3.9"
 	if answers.Keywords != "" {
 		data.Keywords = regexp.MustCompile(`[\s,]+`).Split(strings.TrimSpace(answers.Keywords), -1)
 	}
@@ -164,20 +176,19 @@ func generateConvertedSkill(data *scaffold.SkillData, sourceDir string) error {
 		data.LicenseFile = foundLicenseFile
 	} else {
 		defaultLicensePath := filepath.Join(destDir, "LICENSE")
-		os.WriteFile(defaultLicensePath, []byte("License content to be added here."), 0644)
+		os.WriteFile(defaultLicensePath, []byte(scaffold.LicenseText(*data)), 0644)
 		data.LicenseFile = "LICENSE"
 	}
 
-	// Create default assets/icon.svg if it wasn't copied.
+	// Generate the icon if it wasn't copied.
 	iconPath := filepath.Join(destDir, data.Icon)
 	if _, err := os.Stat(iconPath); os.IsNotExist(err) {
 		assetsDir := filepath.Dir(iconPath)
 		os.MkdirAll(assetsDir, 0755)
-		iconContent := `<svg xmlns="http://www.w3.org/2000/svg" width="100" height="100" viewBox="0 0 24 24"><path d="M12 2L2 7v10l10 5 10-5V7L12 2zm0 2.23L19.77 7 12 11.77 4.23 7 12 4.23zM3 8.5l9 5.06v9.44L3 17.5V8.5zm18 0v9l-9 5.06v-9.44L21 8.5z"/></svg>`
-		if err := os.WriteFile(iconPath, []byte(iconContent), 0644); err != nil {
+		if err := os.WriteFile(iconPath, []byte(icon.Generate(data.Name)), 0644); err != nil {
 			return fmt.Errorf("failed to create default icon: %w", err)
 		}
-		fmt.Println("✅ Created default assets/icon.svg as it was missing from source.")
+		fmt.Printf("✅ Generated %s as it was missing from source.\n", data.Icon)
 	}
 
 	// Write the final manifest
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/skilzy/skilzy-cli/semver"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deprecateOrg     string
+	deprecateMessage string
+	deprecateUndo    bool
+)
+
+var deprecateCmd = &cobra.Command{
+	Use:   "deprecate <skill>[@range]",
+	Short: "Mark a skill or a range of its versions as deprecated",
+	Long: `Deprecates every version of a skill, or only the versions matching a range.
+Deprecated versions stay installable, but search results and 'skilzy me skills'
+show the deprecation message.
+
+Examples:
+  skilzy deprecate my-skill --message "Use my-skill-v2 instead"
+  skilzy deprecate "my-skill@<2.0.0" --message "1.x is no longer maintained"
+  skilzy deprecate my-skill --undo`,
+	Args: cobra.ExactArgs(1),
+	Run:  runDeprecate,
+}
+
+func init() {
+	rootCmd.AddCommand(deprecateCmd)
+	deprecateCmd.Flags().StringVar(&deprecateOrg, "org", "", "The organization the skill is published under")
+	deprecateCmd.Flags().StringVarP(&deprecateMessage, "message", "m", "", "The deprecation message shown to users")
+	deprecateCmd.Flags().BoolVar(&deprecateUndo, "undo", false, "Remove the deprecation")
+	deprecateCmd.MarkFlagsMutuallyExclusive("message", "undo")
+}
+
+func runDeprecate(cmd *cobra.Command, args []string) {
+	name, versionRange := splitSkillRef(args[0])
+	if !deprecateUndo && deprecateMessage == "" {
+		fmt.Println("✗ A deprecation message is required (--message), or use --undo to remove one.")
+		os.Exit(1)
+	}
+	if versionRange != "" {
+		if _, err := semver.ParseRange(versionRange); err != nil {
+			fmt.Printf("✗ %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	target := name
+	if versionRange != "" {
+		target = name + "@" + versionRange
+	}
+
+	client := newAuthenticatedClient()
+	if err := client.DeprecateSkill(deprecateOrg, name, versionRange, deprecateMessage); err != nil {
+		fmt.Printf("✗ Failed to update %s: %v\n", target, err)
+		os.Exit(1)
+	}
+
+	if deprecateUndo {
+		fmt.Printf("✓ Removed deprecation from %s\n", target)
+		return
+	}
+	fmt.Printf("✓ Deprecated %s\n", target)
+	fmt.Printf("  Message: %s\n", deprecateMessage)
+}
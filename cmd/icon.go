@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/skilzy/skilzy-cli/icon"
+	"github.com/spf13/cobra"
+)
+
+// defaultIconPath is where init and convert put a skill's icon.
+const defaultIconPath = "assets/icon.svg"
+
+var (
+	iconOutput string
+	iconForce  bool
+)
+
+var iconCmd = &cobra.Command{
+	Use:   "icon",
+	Short: "Create a skill's icon",
+}
+
+var iconGenerateCmd = &cobra.Command{
+	Use:   "generate [name]",
+	Short: "Generate an identicon-style SVG icon for the skill",
+	Long: `Generates a square SVG icon with a symmetric pattern and a color derived from
+a name, by default the skill's name from skill.json. The same name always
+gives the same icon; 'skilzy init' and 'skilzy convert' use it for skills
+without an icon.
+
+The icon is written to the path in the manifest's 'icon' field (or
+` + defaultIconPath + `). Use --output - to print it instead.
+
+Examples:
+  skilzy icon generate
+  skilzy icon generate --force
+  skilzy icon generate pdf-tools --output -`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runIconGenerate,
+}
+
+func init() {
+	rootCmd.AddCommand(iconCmd)
+	iconCmd.AddCommand(iconGenerateCmd)
+	iconGenerateCmd.Flags().StringVarP(&iconOutput, "output", "o", "", "Path to write the icon to, or '-' for standard output")
+	iconGenerateCmd.Flags().BoolVarP(&iconForce, "force", "f", false, "Overwrite an existing icon")
+}
+
+func runIconGenerate(cmd *cobra.Command, args []string) {
+	var data struct {
+		Name string `json:"name"`
+		Icon string `json:"icon"`
+	}
+	if content, err := os.ReadFile("skill.json"); err == nil {
+		if err := json.Unmarshal(content, &data); err != nil {
+			fmt.Printf("✗ Failed to parse skill.json: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	name := data.Name
+	if len(args) > 0 {
+		name = args[0]
+	}
+	if name == "" {
+		fmt.Println("✗ No skill.json found in the current directory; pass the name to generate an icon for.")
+		os.Exit(1)
+	}
+	svg := icon.Generate(name)
+
+	if iconOutput == "-" {
+		fmt.Print(svg)
+		return
+	}
+	outputPath := iconOutput
+	if outputPath == "" {
+		outputPath = defaultIconPath
+		if strings.EqualFold(filepath.Ext(data.Icon), ".svg") {
+			outputPath = data.Icon
+		}
+	}
+	if _, err := os.Stat(outputPath); err == nil && !iconForce {
+		fmt.Printf("✗ %s already exists. Use --force to overwrite it.\n", outputPath)
+		os.Exit(1)
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		fmt.Printf("✗ Failed to create directory for the icon: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(outputPath, []byte(svg), 0644); err != nil {
+		fmt.Printf("✗ Failed to write %s: %v\n", outputPath, err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Generated icon for '%s' at %s\n", name, outputPath)
+	if data.Name != "" && filepath.ToSlash(filepath.Clean(outputPath)) != filepath.ToSlash(filepath.Clean(data.Icon)) {
+		fmt.Printf("   Set 'icon' in skill.json to use it: skilzy manifest set icon %s\n", filepath.ToSlash(outputPath))
+	}
+}
+
+// performIconChecks ensures the manifest's icon is an SVG or PNG of a usable
+// shape and size, and that an SVG icon is self-contained and free of scripts.
+func performIconChecks(skillDir string, manifestContent []byte) (errors, warnings []string) {
+	var data struct {
+		Icon string `json:"icon"`
+	}
+	json.Unmarshal(manifestContent, &data)
+	if data.Icon == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(filepath.Join(skillDir, data.Icon))
+	if err != nil {
+		// A missing icon is reported by the filesystem checks
+		return nil, nil
+	}
+
+	info, err := icon.Inspect(content)
+	if err != nil {
+		return []string{fmt.Sprintf("Icon '%s': %v.", data.Icon, err)}, nil
+	}
+	if ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(data.Icon)), "."); ext != info.Format {
+		errors = append(errors, fmt.Sprintf("Icon '%s' is a %s image, but its extension is '%s'.", data.Icon, strings.ToUpper(info.Format), filepath.Ext(data.Icon)))
+	}
+
+	switch {
+	case info.Size > icon.MaxFileSize:
+		errors = append(errors, fmt.Sprintf("Icon '%s' is %s; icons must be at most %s.", data.Icon, formatSize(int64(info.Size)), formatSize(icon.MaxFileSize)))
+	case info.Size > icon.RecommendedFileSize:
+		warnings = append(warnings, fmt.Sprintf("Icon '%s' is %s; consider keeping it under %s.", data.Icon, formatSize(int64(info.Size)), formatSize(icon.RecommendedFileSize)))
+	}
+
+	switch ratio := info.AspectRatio(); {
+	case ratio == 0:
+		warnings = append(warnings, fmt.Sprintf("Icon '%s' has no viewBox or pixel width and height, so its shape is unknown.", data.Icon))
+	case ratio > icon.MaxAspectRatio:
+		errors = append(errors, fmt.Sprintf("Icon '%s' is %gx%g; icons are displayed square and must not be more than %g times as wide as they are tall (or vice versa).", data.Icon, info.Width, info.Height, icon.MaxAspectRatio))
+	case ratio > 1.05: // nearly square icons scale without visible padding
+		warnings = append(warnings, fmt.Sprintf("Icon '%s' is %gx%g; icons are displayed square, so it will be padded.", data.Icon, info.Width, info.Height))
+	}
+
+	if info.Format == icon.FormatPNG {
+		switch smallest := min(info.Width, info.Height); {
+		case smallest < icon.MinSize:
+			errors = append(errors, fmt.Sprintf("Icon '%s' is %gx%g pixels; PNG icons must be at least %dx%d.", data.Icon, info.Width, info.Height, icon.MinSize, icon.MinSize))
+		case smallest < icon.RecommendedSize:
+			warnings = append(warnings, fmt.Sprintf("Icon '%s' is %gx%g pixels; use at least %dx%d (or an SVG) to look sharp.", data.Icon, info.Width, info.Height, icon.RecommendedSize, icon.RecommendedSize))
+		}
+	}
+
+	for _, problem := range info.Unsafe {
+		errors = append(errors, fmt.Sprintf("Icon '%s' contains %s; SVG icons must not have scripts or external references.", data.Icon, problem))
+	}
+	return errors, warnings
+}
@@ -8,50 +8,60 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/skilzy/skilzy-cli/license"
 	"github.com/skilzy/skilzy-cli/scaffold"
 	"github.com/skilzy/skilzy-cli/utils"
 	"github.com/spf13/cobra"
 )
 
-var yesFlag bool
+var (
+	yesFlag           bool
+	runtimeFlag       string
+	templateFlag      string
+	listTemplatesFlag bool
+)
 
 var initCmd = &cobra.Command{
 	Use:   "init [skill-name]",
 	Short: "Initialize a new skill in the current directory",
-	Long:  `Creates a new skill directory with a valid 'skill.json' manifest.`,
-	Args:  cobra.MaximumNArgs(1),
-	Run:   runInit,
+	Long: `Creates a new skill directory with a valid 'skill.json' manifest.
+
+The skill's files come from a template: one of the built-in templates (see
+--list-templates), a local directory or a git URL. Files ending in '.tmpl' are
+rendered with Go's text/template and can use the manifest fields (e.g. {{.Name}},
+{{.Description}}, {{.Runtime.Version}}) and the answers to the questions the
+template defines in its template.json ({{.Answers.<name>}}). A template that
+declares a runtime, such as python-script, only creates skills for that runtime.
+
+Examples:
+  skilzy init
+  skilzy init my-skill --template python-script --yes
+  skilzy init my-skill --template ./templates/company-skill
+  skilzy init my-skill --template https://github.com/acme/skill-template.git#v2`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runInit,
 }
 
 func init() {
 	rootCmd.AddCommand(initCmd)
 	initCmd.Flags().BoolVarP(&yesFlag, "yes", "y", false, "Skip interactive prompts and use default values")
+	initCmd.Flags().StringVar(&runtimeFlag, "runtime", "", "Runtime of the skill: python, node, deno, shell or none (defaults to the template's runtime, or python; must match the template's runtime if it has one)")
+	initCmd.Flags().StringVarP(&templateFlag, "template", "t", scaffold.DefaultTemplate, "Template to create the skill from: a built-in name, a directory or a git URL")
+	initCmd.Flags().BoolVar(&listTemplatesFlag, "list-templates", false, "List the built-in templates and exit")
 }
 
 func runInit(cmd *cobra.Command, args []string) {
-	data := scaffold.SkillData{}
-	isInteractive := !yesFlag && len(args) == 0
-
-	if isInteractive {
-		 if err := runInteractiveSurvey(&data); err != nil {
-			fmt.Printf("✗ Aborted. %v\n", err)
+	if listTemplatesFlag {
+		if err := printTemplates(); err != nil {
+			fmt.Printf("✗ %v\n", err)
 			os.Exit(1)
 		}
-	} else {
-		skillName := "my-new-skill"
-		if len(args) > 0 {
-			skillName = args[0]
-			if err := validateSkillName(skillName); err != nil {
-				fmt.Printf("✗ Invalid skill name: %v\n", err)
-				os.Exit(1)
-			}
-		}
-		fmt.Println("Running in non-interactive mode...")
-		populateDefaultData(&data, skillName)
+		return
 	}
 
-	if err := scaffold.Create(data); err != nil {
-		fmt.Printf("✗ Error creating skill: %v\n", err)
+	data, err := initSkill(cmd, args)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
 		os.Exit(1)
 	}
 
@@ -61,7 +71,81 @@ func runInit(cmd *cobra.Command, args []string) {
 	fmt.Printf("2. Run 'cd %s && skilzy validate' to check your work.\n", data.Name)
 }
 
-func runInteractiveSurvey(data *scaffold.SkillData) error {
+// initSkill gathers the skill's details and creates it from the chosen template.
+func initSkill(cmd *cobra.Command, args []string) (*scaffold.SkillData, error) {
+	data := &scaffold.SkillData{}
+	isInteractive := !yesFlag && len(args) == 0
+
+	if runtimeFlag != "" {
+		if err := validateRuntimeType(runtimeFlag); err != nil {
+			return nil, err
+		}
+	}
+	skillName := "my-new-skill"
+	if len(args) > 0 {
+		skillName = args[0]
+		if err := validateSkillName(skillName); err != nil {
+			return nil, fmt.Errorf("Invalid skill name: %v", err)
+		}
+	}
+
+	templateSource := templateFlag
+	if isInteractive && !cmd.Flags().Changed("template") {
+		name, err := askTemplate()
+		if err != nil {
+			return nil, fmt.Errorf("Aborted. %v", err)
+		}
+		templateSource = name
+	}
+	tmpl, err := scaffold.LoadTemplate(templateSource)
+	if err != nil {
+		return nil, err
+	}
+	defer tmpl.Close()
+
+	// The runtime comes from --runtime, then the template, then python
+	runtime := scaffold.Runtime{Type: scaffold.RuntimePython}
+	switch {
+	case runtimeFlag != "":
+		runtime.Type = runtimeFlag
+	case tmpl.Runtime != nil:
+		runtime = *tmpl.Runtime
+		if err := validateRuntimeType(runtime.Type); err != nil {
+			return nil, fmt.Errorf("template '%s': %v", tmpl.Name, err)
+		}
+	}
+	if err := tmpl.CheckRuntime(runtime.Type); err != nil {
+		return nil, err
+	}
+	if runtime.Version == "" {
+		runtime.Version = scaffold.DefaultRuntimeVersion(runtime.Type)
+	}
+
+	if isInteractive {
+		if err := runInteractiveSurvey(data, runtime); err != nil {
+			return nil, fmt.Errorf("Aborted. %v", err)
+		}
+		if err := tmpl.CheckRuntime(data.Runtime.Type); err != nil {
+			return nil, err
+		}
+	} else {
+		fmt.Println("Running in non-interactive mode...")
+		populateDefaultData(data, skillName, runtime)
+	}
+
+	answers, err := askTemplatePrompts(tmpl, isInteractive)
+	if err != nil {
+		return nil, fmt.Errorf("Aborted. %v", err)
+	}
+	tmpl.ApplyAnswers(data, answers)
+
+	if err := scaffold.Create(*data, tmpl, answers); err != nil {
+		return nil, fmt.Errorf("Error creating skill: %v", err)
+	}
+	return data, nil
+}
+
+func runInteractiveSurvey(data *scaffold.SkillData, defaultRuntime scaffold.Runtime) error {
 	defaultAuthor := utils.GetGitUserName()
 	answers := struct {
 		Name          string
@@ -77,7 +161,7 @@ func runInteractiveSurvey(data *scaffold.SkillData) error {
 		{Name: "name", Prompt: &survey.Input{Message: "Skill Name:", Help: "A unique, hyphen-case identifier."}, Validate: func(val interface{}) error { return validateSkillName(val.(string)) }},
 		{Name: "description", Prompt: &survey.Input{Message: "Description:"}, Validate: survey.MinLength(20)},
 		{Name: "author", Prompt: &survey.Input{Message: "Author:", Default: defaultAuthor}, Validate: survey.Required},
-		{Name: "license", Prompt: &survey.Select{Message: "License:", Options: []string{"MIT", "Apache-2.0", "GPL-3.0", "BSD-3-Clause"}, Default: "MIT"}},
+		{Name: "license", Prompt: &survey.Select{Message: "License:", Options: license.Common(), Default: "MIT"}},
 	}
 
 	fmt.Println("This utility will walk you through creating a new skill.")
@@ -89,19 +173,29 @@ func runInteractiveSurvey(data *scaffold.SkillData) error {
 	survey.AskOne(&survey.Input{Message: "GitHub Repository URL (optional):"}, &answers.RepositoryURL)
 	survey.AskOne(&survey.Input{Message: "Keywords (comma-separated, optional):"}, &answers.Keywords)
 
+	runtime, err := askRuntime(defaultRuntime)
+	if err != nil {
+		return err
+	}
+	data.Runtime = runtime
+
+	permissions, err := askPermissions()
+	if err != nil {
+		return err
+	}
+	data.Permissions = permissions
+
 	data.Name = answers.Name
 	data.Description = answers.Description
 	data.Author = answers.Author
 	data.License = answers.License
 	data.Version = "0.1.0"
 	data.Entrypoint = "README.md"
-	data.Icon = "assets/icon.svg"
+	data.Icon = defaultIconPath
 	data.LicenseFile = "LICENSE"
 	if answers.RepositoryURL != "" {
 		data.Repository = &scaffold.Repository{Type: "git", URL: answers.RepositoryURL}
 	}
-	data.Runtime.Type = "python"
-	data.Runtime.Version = ">=3.9"
 	if answers.Keywords != "" {
 		data.Keywords = regexp.MustCompile(`[\s,]+`).Split(strings.TrimSpace(answers.Keywords), -1)
 	}
@@ -109,7 +203,7 @@ func runInteractiveSurvey(data *scaffold.SkillData) error {
 	return nil
 }
 
-func populateDefaultData(data *scaffold.SkillData, skillName string) {
+func populateDefaultData(data *scaffold.SkillData, skillName string, runtime scaffold.Runtime) {
 	data.Name = skillName
 	data.Version = "0.1.0"
 	data.Description = "A new Skilzy skill. Please provide a detailed description of its capabilities."
@@ -120,9 +214,8 @@ func populateDefaultData(data *scaffold.SkillData, skillName string) {
 	data.License = "MIT"
 	data.LicenseFile = "LICENSE"
 	data.Entrypoint = "README.md"
-	data.Icon = "assets/icon.svg"
-	data.Runtime.Type = "python"
-	data.Runtime.Version = ">=3.9"
+	data.Icon = defaultIconPath
+	data.Runtime = runtime
 	data.Keywords = []string{}
 	data.Dependencies = &scaffold.Dependencies{
 		System: []string{},
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/skilzy/skilzy-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	keyName    string
+	keyScopes  string
+	keyExpires string
+	keyYes     bool
+)
+
+var meKeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage your API keys",
+	Long: `Commands for listing, creating and revoking API keys.
+
+Keys carry one or more scopes:
+  read     read access to your account and skills
+  publish  publish and manage skill versions
+  admin    full access, including key management`,
+}
+
+var meKeysListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your API keys",
+	Args:  cobra.NoArgs,
+	Run:   runMeKeysList,
+}
+
+var meKeysCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new scoped API key",
+	Long: `Creates a new API key with the given scopes and expiration. The key is
+printed once and cannot be retrieved again.
+
+Examples:
+  skilzy me keys create --name ci-publish --scopes publish --expires 90d
+  skilzy me keys create --name laptop --scopes read,publish --expires never`,
+	Args: cobra.NoArgs,
+	Run:  runMeKeysCreate,
+}
+
+var meKeysRevokeCmd = &cobra.Command{
+	Use:   "revoke <key-id>",
+	Short: "Revoke an API key",
+	Long:  `Permanently revokes an API key. Any client using it will immediately lose access.`,
+	Args:  cobra.ExactArgs(1),
+	Run:   runMeKeysRevoke,
+}
+
+func init() {
+	meCmd.AddCommand(meKeysCmd)
+	meKeysCmd.AddCommand(meKeysListCmd)
+	meKeysCmd.AddCommand(meKeysCreateCmd)
+	meKeysCmd.AddCommand(meKeysRevokeCmd)
+
+	meKeysCreateCmd.Flags().StringVar(&keyName, "name", "", "A name to identify the key")
+	meKeysCreateCmd.Flags().StringVar(&keyScopes, "scopes", utils.ScopeRead, "Comma-separated scopes: read, publish, admin")
+	meKeysCreateCmd.Flags().StringVar(&keyExpires, "expires", "90d", "Expiration in days (e.g. 30d) or 'never'")
+	meKeysCreateCmd.MarkFlagRequired("name")
+
+	meKeysRevokeCmd.Flags().BoolVarP(&keyYes, "yes", "y", false, "Skip the confirmation prompt")
+}
+
+func runMeKeysList(cmd *cobra.Command, args []string) {
+	client := newAuthenticatedClient()
+
+	keys, err := client.ListAPIKeys()
+	if err != nil {
+		fmt.Printf("✗ Failed to retrieve API keys: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(keys) == 0 {
+		fmt.Println("You have no API keys.")
+		return
+	}
+
+	fmt.Printf("You have %d API key(s):\n\n", len(keys))
+
+	// Print table header
+	fmt.Printf("%-20s %-20s %-12s %-22s %-12s %s\n", "ID", "NAME", "PREFIX", "SCOPES", "EXPIRES", "LAST USED")
+	fmt.Println(strings.Repeat("-", 100))
+
+	// Print results
+	for _, key := range keys {
+		name := key.Name
+		if len(name) > 20 {
+			name = name[:17] + "..."
+		}
+		expires := "never"
+		if key.ExpiresAt != nil {
+			expires = key.ExpiresAt.Local().Format("2006-01-02")
+		}
+		lastUsed := "never"
+		if key.LastUsedAt != nil {
+			lastUsed = key.LastUsedAt.Local().Format("2006-01-02")
+		}
+		fmt.Printf("%-20s %-20s %-12s %-22s %-12s %s\n", key.ID, name, key.Prefix, strings.Join(key.Scopes, ","), expires, lastUsed)
+	}
+}
+
+func runMeKeysCreate(cmd *cobra.Command, args []string) {
+	scopes, err := parseScopes(keyScopes)
+	if err != nil {
+		fmt.Printf("✗ Invalid scopes: %v\n", err)
+		os.Exit(1)
+	}
+
+	expiresInDays, err := parseExpiry(keyExpires)
+	if err != nil {
+		fmt.Printf("✗ Invalid expiration: %v\n", err)
+		os.Exit(1)
+	}
+
+	client := newAuthenticatedClient()
+	created, err := client.CreateAPIKey(utils.CreateAPIKeyRequest{
+		Name:          keyName,
+		Scopes:        scopes,
+		ExpiresInDays: expiresInDays,
+		NeverExpires:  expiresInDays == 0,
+	})
+	if err != nil {
+		fmt.Printf("✗ Failed to create API key: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("✓ API key created successfully")
+	fmt.Printf("  - ID: %s\n", created.ID)
+	fmt.Printf("  - Name: %s\n", created.Name)
+	fmt.Printf("  - Scopes: %s\n", strings.Join(created.Scopes, ", "))
+	if created.ExpiresAt != nil {
+		fmt.Printf("  - Expires: %s\n", created.ExpiresAt.Local().Format("2006-01-02"))
+	} else {
+		fmt.Println("  - Expires: never")
+	}
+
+	fmt.Printf("\n%s\n\n", created.Key)
+	fmt.Println("⚠️  Copy this key now. It will not be shown again.")
+}
+
+func runMeKeysRevoke(cmd *cobra.Command, args []string) {
+	keyID := args[0]
+
+	if !keyYes {
+		confirmed := false
+		prompt := &survey.Confirm{Message: fmt.Sprintf("Revoke API key '%s'? This cannot be undone.", keyID)}
+		if err := survey.AskOne(prompt, &confirmed); err != nil || !confirmed {
+			fmt.Println("✗ Aborted.")
+			os.Exit(1)
+		}
+	}
+
+	client := newAuthenticatedClient()
+	if err := client.RevokeAPIKey(keyID); err != nil {
+		fmt.Printf("✗ Failed to revoke API key: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✓ API key '%s' revoked\n", keyID)
+}
+
+// parseScopes splits a comma-separated scope list and checks each scope is known.
+func parseScopes(value string) ([]string, error) {
+	var scopes []string
+	for _, scope := range strings.Split(value, ",") {
+		scope = strings.TrimSpace(scope)
+		if scope == "" {
+			continue
+		}
+		switch scope {
+		case utils.ScopeRead, utils.ScopePublish, utils.ScopeAdmin:
+			scopes = append(scopes, scope)
+		default:
+			return nil, fmt.Errorf("unknown scope '%s' (expected read, publish or admin)", scope)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("at least one scope is required")
+	}
+	return scopes, nil
+}
+
+// parseExpiry converts an expiration such as "90d" or "never" into a number of days, 0 meaning never.
+func parseExpiry(value string) (int, error) {
+	if value == "never" {
+		return 0, nil
+	}
+	days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+	if err != nil || days <= 0 {
+		return 0, fmt.Errorf("'%s' must be a positive number of days (e.g. 30d) or 'never'", value)
+	}
+	return days, nil
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/skilzy/skilzy-cli/license"
+)
+
+// placeholderLicenseSize is the size below which a license file is taken to be
+// a placeholder rather than the text of a license.
+const placeholderLicenseSize = 200
+
+// performLicenseChecks ensures the manifest's license is a valid SPDX
+// expression and that the license file plausibly contains that license: the
+// text of every license joined by AND, or of one of the alternatives of OR.
+func performLicenseChecks(skillDir string, manifestContent []byte) (errors, warnings []string) {
+	var data struct {
+		License     string `json:"license"`
+		LicenseFile string `json:"licenseFile"`
+	}
+	json.Unmarshal(manifestContent, &data)
+
+	expr, err := license.ParseExpression(data.License)
+	if err != nil {
+		message := fmt.Sprintf("'license' is not a valid SPDX license expression: %v", err)
+		if !strings.HasSuffix(message, "?") {
+			message += "."
+		}
+		return []string{message}, nil
+	}
+	if canonical := expr.String(); canonical != data.License {
+		warnings = append(warnings, fmt.Sprintf("'license' should be written as '%s'.", canonical))
+	}
+	for _, id := range expr.Deprecated() {
+		if replacement := license.Replacement(id); replacement != "" {
+			warnings = append(warnings, fmt.Sprintf("The SPDX identifier '%s' is deprecated; use '%s' instead.", id, replacement))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("The SPDX identifier '%s' is deprecated.", id))
+		}
+	}
+
+	if data.LicenseFile == "" {
+		return errors, warnings
+	}
+	content, err := os.ReadFile(filepath.Join(skillDir, data.LicenseFile))
+	if err != nil {
+		// A missing license file is reported by the filesystem checks
+		return errors, warnings
+	}
+	text := string(content)
+
+	missing := expr.MissingTexts(text)
+	if missing == nil {
+		return errors, warnings
+	}
+	needed := strings.NewReplacer(" AND ", " and ", " OR ", " or ").Replace(missing.String())
+
+	declared := map[string]bool{}
+	for _, id := range expr.Licenses() {
+		declared[id] = true
+	}
+	var found, foreign []string
+	for _, id := range license.Identify(text) {
+		found = append(found, id)
+		if !declared[id] {
+			foreign = append(foreign, id)
+		}
+	}
+	switch {
+	case len(foreign) > 0:
+		warnings = append(warnings, fmt.Sprintf("%s looks like the %s license, but 'license' is '%s'.", data.LicenseFile, strings.Join(foreign, " or "), data.License))
+	case len(found) > 0:
+		warnings = append(warnings, fmt.Sprintf("%s contains the text of %s but not of %s, which 'license' also requires.", data.LicenseFile, strings.Join(found, " and "), needed))
+	case len(strings.TrimSpace(text)) < placeholderLicenseSize:
+		warnings = append(warnings, fmt.Sprintf("%s does not contain the full license text; add the text of %s.", data.LicenseFile, needed))
+	default:
+		warnings = append(warnings, fmt.Sprintf("%s does not appear to contain the text of %s.", data.LicenseFile, needed))
+	}
+	return errors, warnings
+}
@@ -3,6 +3,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -11,26 +12,67 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var apiKeyFlag string
+var (
+	apiKeyFlag           string
+	withTokenFlag        bool
+	credentialStoreFlag  string
+	credentialHelperFlag string
+	migrateFlag          bool
+)
 
 var loginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Authenticate with the Skilzy Registry",
-	Long:  `Save your API key for authenticated operations like publishing skills.`,
-	Run:   runLogin,
+	Long: `Save your API key for authenticated operations like publishing skills.
+
+In CI, prefer setting the SKILZY_API_KEY environment variable, which takes
+precedence over the saved key, or pipe the key in with --with-token so it
+never appears in shell history or process listings:
+
+  echo "$SKILZY_TOKEN" | skilzy login --with-token
+
+By default the key is stored in plaintext in ~/.skilzy/config.json. Use --store
+to select another backend:
+
+  file       plaintext config file (default)
+  encrypted  AES-encrypted file, unlocked with a passphrase (or $SKILZY_CREDENTIAL_PASSPHRASE)
+  helper     an external git-credential style helper, e.g.
+             --store helper --credential-helper git-credential-osxkeychain
+
+Use --migrate to move an existing key into the selected store.`,
+	Run: runLogin,
 }
 
 func init() {
 	rootCmd.AddCommand(loginCmd)
-	loginCmd.Flags().StringVar(&apiKeyFlag, "api-key", "", "Your Skilzy API key (will prompt if not provided)")
+	loginCmd.Flags().StringVar(&apiKeyFlag, "api-key", "", "Your Skilzy API key (will prompt if not provided; visible in shell history)")
+	loginCmd.Flags().BoolVar(&withTokenFlag, "with-token", false, "Read the API key from standard input")
+	loginCmd.Flags().StringVar(&credentialStoreFlag, "store", "", "Credential store backend: file, encrypted or helper")
+	loginCmd.Flags().StringVar(&credentialHelperFlag, "credential-helper", "", "Credential helper command for the 'helper' store")
+	loginCmd.Flags().BoolVar(&migrateFlag, "migrate", false, "Move the saved API key into the store selected with --store")
+	loginCmd.MarkFlagsMutuallyExclusive("api-key", "with-token")
+	loginCmd.MarkFlagsMutuallyExclusive("migrate", "api-key")
+	loginCmd.MarkFlagsMutuallyExclusive("migrate", "with-token")
 }
 
 func runLogin(cmd *cobra.Command, args []string) {
+	if migrateFlag {
+		runMigrate()
+		return
+	}
+
 	var apiKey string
 
-	// Get API key from flag or prompt
+	// Get API key from flag, stdin or prompt
 	if apiKeyFlag != "" {
 		apiKey = apiKeyFlag
+	} else if withTokenFlag {
+		input, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Printf("✗ Error reading API key from stdin: %v\n", err)
+			os.Exit(1)
+		}
+		apiKey = string(input)
 	} else {
 		// Interactive prompt
 		prompt := &survey.Password{
@@ -52,7 +94,8 @@ func runLogin(cmd *cobra.Command, args []string) {
 	}
 
 	// Save API key
-	if err := utils.SaveAPIKey(apiKey); err != nil {
+	store, err := saveToCredentialStore(apiKey)
+	if err != nil {
 		fmt.Printf("✗ Failed to save API key: %v\n", err)
 		os.Exit(1)
 	}
@@ -60,6 +103,102 @@ func runLogin(cmd *cobra.Command, args []string) {
 	fmt.Println("✓ API key saved successfully")
 	
 	// Show where it was saved
-	configPath, _ := utils.GetConfigPath()
-	fmt.Printf("  Saved to: %s\n", configPath)
-}
\ No newline at end of file
+	fmt.Printf("  Saved to: %s\n", store.Describe())
+
+	if os.Getenv(utils.APIKeyEnvVar) != "" {
+		fmt.Printf("\nℹ️  %s is set and will be used instead of the saved key.\n", utils.APIKeyEnvVar)
+	}
+}
+
+// runMigrate moves the saved API key from the current credential store into
+// the one selected with --store.
+func runMigrate() {
+	if credentialStoreFlag == "" {
+		fmt.Println("✗ --migrate requires --store to select the destination credential store.")
+		os.Exit(1)
+	}
+
+	current, err := utils.GetCredentialStore()
+	if err != nil {
+		fmt.Printf("✗ Failed to open current credential store: %v\n", err)
+		os.Exit(1)
+	}
+
+	apiKey, err := current.Get()
+	if err != nil {
+		fmt.Printf("✗ Failed to read API key from %s: %v\n", current.Describe(), err)
+		os.Exit(1)
+	}
+	if apiKey == "" {
+		fmt.Printf("✗ No API key found in %s.\n", current.Describe())
+		fmt.Println("  Please run 'skilzy login' first.")
+		os.Exit(1)
+	}
+
+	store, err := saveToCredentialStore(apiKey)
+	if err != nil {
+		fmt.Printf("✗ Migration failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("✓ API key migrated successfully")
+	fmt.Printf("  From: %s\n", current.Describe())
+	fmt.Printf("  To:   %s\n", store.Describe())
+}
+
+// saveToCredentialStore stores the API key in the store selected by --store,
+// or the configured store if the flag is not set. When the store changes, the
+// new selection is recorded in the config and the key is erased from the
+// previous store.
+func saveToCredentialStore(apiKey string) (utils.CredentialStore, error) {
+	config, err := utils.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := utils.NewCredentialStore(config.CredentialStore, config)
+	if err != nil {
+		return nil, err
+	}
+
+	storeName := config.CredentialStore
+	if credentialStoreFlag != "" {
+		storeName = credentialStoreFlag
+	}
+	if credentialHelperFlag != "" {
+		config.CredentialHelper = credentialHelperFlag
+	}
+
+	store, err := utils.NewCredentialStore(storeName, config)
+	if err != nil {
+		return nil, err
+	}
+	if store.Describe() == previous.Describe() {
+		return store, store.Store(apiKey)
+	}
+
+	if err := store.Store(apiKey); err != nil {
+		return nil, err
+	}
+
+	// Reload so that keys written to config.json by the file store are kept.
+	config, err = utils.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if storeName == utils.CredentialStoreFile {
+		storeName = ""
+	}
+	config.CredentialStore = storeName
+	if credentialHelperFlag != "" {
+		config.CredentialHelper = credentialHelperFlag
+	}
+	if err := utils.SaveConfig(config); err != nil {
+		return nil, err
+	}
+
+	if err := previous.Erase(); err != nil {
+		fmt.Printf("⚠️  Could not remove the API key from %s: %v\n", previous.Describe(), err)
+	}
+	return store, nil
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/skilzy/skilzy-cli/manifest"
+	"github.com/spf13/cobra"
+)
+
+var manifestSetJSON bool
+
+var manifestCmd = &cobra.Command{
+	Use:   "manifest",
+	Short: "Read and edit fields of skill.json",
+	Long: `Commands for reading and editing skill.json in the current directory by path.
+
+Edits change only the value at the path: unknown fields, key order and
+formatting are preserved.
+
+Paths use dots for object keys and brackets for array indices:
+  version
+  runtime.version
+  keywords[0]
+  permissions.network.allowedHosts
+  ["key.with.dots"]`,
+}
+
+var manifestGetCmd = &cobra.Command{
+	Use:   "get <path>",
+	Short: "Print the value at a path",
+	Long: `Prints the value at a path. Strings are printed as-is; objects, arrays,
+numbers and booleans are printed as JSON.
+
+Examples:
+  skilzy manifest get version
+  skilzy manifest get permissions`,
+	Args: cobra.ExactArgs(1),
+	Run:  runManifestGet,
+}
+
+var manifestSetCmd = &cobra.Command{
+	Use:   "set <path> <value>",
+	Short: "Set the value at a path",
+	Long: `Sets the value at a path, creating missing parent objects. The value is
+stored as a string unless --json is given. Use an index equal to the length
+of an array to append to it.
+
+Examples:
+  skilzy manifest set runtime.version ">=3.10"
+  skilzy manifest set keywords[2] pdf
+  skilzy manifest set --json permissions.network.allowedHosts '["api.example.com"]'`,
+	Args: cobra.ExactArgs(2),
+	Run:  runManifestSet,
+}
+
+var manifestUnsetCmd = &cobra.Command{
+	Use:   "unset <path>",
+	Short: "Remove the value at a path",
+	Long: `Removes a key from an object or an element from an array.
+
+Examples:
+  skilzy manifest unset repository
+  skilzy manifest unset keywords[0]`,
+	Args: cobra.ExactArgs(1),
+	Run:  runManifestUnset,
+}
+
+func init() {
+	rootCmd.AddCommand(manifestCmd)
+	manifestCmd.AddCommand(manifestGetCmd)
+	manifestCmd.AddCommand(manifestSetCmd)
+	manifestCmd.AddCommand(manifestUnsetCmd)
+
+	manifestSetCmd.Flags().BoolVar(&manifestSetJSON, "json", false, "Parse the value as JSON instead of storing it as a string")
+}
+
+func runManifestGet(cmd *cobra.Command, args []string) {
+	doc, _ := loadManifest()
+
+	raw, ok, err := doc.GetRaw(args[0])
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		os.Exit(1)
+	}
+	if !ok {
+		fmt.Printf("✗ '%s' is not set in skill.json\n", args[0])
+		os.Exit(1)
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		fmt.Println(s)
+		return
+	}
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, raw, "", "  "); err != nil {
+		pretty.Write(raw)
+	}
+	fmt.Println(pretty.String())
+}
+
+func runManifestSet(cmd *cobra.Command, args []string) {
+	doc, manifestPath := loadManifest()
+
+	var err error
+	if manifestSetJSON {
+		err = doc.SetRaw(args[0], json.RawMessage(args[1]))
+	} else {
+		err = doc.Set(args[0], args[1])
+	}
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		os.Exit(1)
+	}
+	saveManifest(doc, manifestPath)
+	fmt.Printf("✅ Set %s\n", args[0])
+}
+
+func runManifestUnset(cmd *cobra.Command, args []string) {
+	doc, manifestPath := loadManifest()
+
+	removed, err := doc.Unset(args[0])
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		os.Exit(1)
+	}
+	if !removed {
+		fmt.Printf("'%s' is not set in skill.json; nothing to remove.\n", args[0])
+		return
+	}
+	saveManifest(doc, manifestPath)
+	fmt.Printf("✅ Removed %s\n", args[0])
+}
+
+// loadManifest loads skill.json from the current directory, exiting on failure.
+func loadManifest() (*manifest.Manifest, string) {
+	skillDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("✗ Error getting current directory: %v\n", err)
+		os.Exit(1)
+	}
+	manifestPath := filepath.Join(skillDir, manifest.FileName)
+	doc, err := manifest.Load(manifestPath)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		os.Exit(1)
+	}
+	return doc, manifestPath
+}
+
+// saveManifest writes an edited manifest and warns if it no longer passes the schema.
+func saveManifest(doc *manifest.Manifest, manifestPath string) {
+	if err := doc.Save(manifestPath); err != nil {
+		fmt.Printf("✗ %v\n", err)
+		os.Exit(1)
+	}
+	if schemaErrors, err := validateManifestSchema(doc.Bytes()); err == nil && len(schemaErrors) > 0 {
+		fmt.Println("⚠️  skill.json no longer passes schema validation:")
+		for _, e := range schemaErrors {
+			fmt.Printf("  - %s\n", e)
+		}
+	}
+}
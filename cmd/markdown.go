@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/skilzy/skilzy-cli/markdown"
+)
+
+// defaultTokenBudget is the SKILL.md size, in estimated tokens, above which
+// validate warns. Agents load all of SKILL.md when a skill is used, so longer
+// material belongs in reference files that are read on demand.
+const defaultTokenBudget = 5000
+
+// referenceDirs hold documentation that agents read on demand.
+var referenceDirs = []string{"reference", "references"}
+
+var (
+	urlSchemePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+	// codePathPattern matches a path into one of the skill's directories
+	// mentioned in inline code, such as `scripts/extract.py`.
+	codePathPattern = regexp.MustCompile(`^(?:\./)?(?:scripts|reference|references|assets)/[^\s*?<>{}$]+$`)
+)
+
+// placeholderPatterns match text that skilzy init and convert put in
+// generated files for the author to replace.
+var placeholderPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\[(?:Describe|Add|List|Provide|Reference|Document|Explain|Insert|Replace|Write)\b[^\]]*\]`),
+	regexp.MustCompile(`This skill (?:is designed to|should be used when)(?:\.\.\.|…)`),
+	regexp.MustCompile(`Run 'skilzy validate' when ready`),
+	regexp.MustCompile(`your-username/`),
+}
+
+// markdownFiles returns the documents to lint, relative to skillDir: the
+// entrypoint, SKILL.md and Markdown files in the reference directories.
+func markdownFiles(skillDir, entrypoint string) []string {
+	var files []string
+	seen := map[string]bool{}
+	add := func(rel string) {
+		rel = filepath.ToSlash(filepath.Clean(rel))
+		if !seen[rel] && fileExists(filepath.Join(skillDir, rel)) {
+			seen[rel] = true
+			files = append(files, rel)
+		}
+	}
+	if strings.EqualFold(filepath.Ext(entrypoint), ".md") {
+		add(entrypoint)
+	}
+	add("SKILL.md")
+	for _, dir := range referenceDirs {
+		filepath.Walk(filepath.Join(skillDir, dir), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			if info.IsDir() && isIgnoredAuditDir(info.Name()) {
+				return filepath.SkipDir
+			}
+			if !info.IsDir() && strings.EqualFold(filepath.Ext(path), ".md") {
+				rel, _ := filepath.Rel(skillDir, path)
+				add(rel)
+			}
+			return nil
+		})
+	}
+	return files
+}
+
+// performMarkdownChecks lints the skill's documentation: links and images
+// must resolve to files in the package, and warnings are given for missing
+// anchors, paths in inline code that do not exist, leftover placeholders,
+// irregular headings and a SKILL.md over the token budget.
+func performMarkdownChecks(skillDir string, manifestContent []byte) (errors, warnings []string) {
+	var data struct {
+		Entrypoint string `json:"entrypoint"`
+	}
+	json.Unmarshal(manifestContent, &data)
+
+	docs := map[string]*markdown.Document{}
+	parse := func(rel string) *markdown.Document {
+		if doc, ok := docs[rel]; ok {
+			return doc
+		}
+		content, err := os.ReadFile(filepath.Join(skillDir, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil
+		}
+		docs[rel] = markdown.Parse(string(content))
+		return docs[rel]
+	}
+
+	for _, rel := range markdownFiles(skillDir, data.Entrypoint) {
+		doc := parse(rel)
+		if doc == nil {
+			continue
+		}
+		warnings = append(warnings, checkHeadings(rel, doc)...)
+		warnings = append(warnings, checkPlaceholders(rel, doc)...)
+
+		for _, link := range doc.Links {
+			e, w := checkLink(skillDir, rel, doc, link, parse)
+			errors = append(errors, e...)
+			warnings = append(warnings, w...)
+		}
+		for _, code := range doc.Code {
+			for _, field := range strings.Fields(code.Text) {
+				if !codePathPattern.MatchString(field) {
+					continue
+				}
+				if !fileExists(filepath.Join(skillDir, filepath.FromSlash(strings.TrimSuffix(field, "/")))) {
+					warnings = append(warnings, fmt.Sprintf("%s:%d: mentions '%s', which is not in the skill.", rel, code.Line, field))
+				}
+			}
+		}
+
+		if rel == "SKILL.md" && validateTokenBudget > 0 {
+			if tokens := markdown.EstimateTokens(doc.Body); tokens > validateTokenBudget {
+				warnings = append(warnings, fmt.Sprintf("SKILL.md is about %d tokens, over the budget of %d; move detail into reference files that agents read when needed.", tokens, validateTokenBudget))
+			}
+		}
+	}
+	return errors, warnings
+}
+
+// checkLink checks one link or image in the document rel.
+func checkLink(skillDir, rel string, doc *markdown.Document, link markdown.Link, parse func(string) *markdown.Document) (errors, warnings []string) {
+	kind := "Link"
+	if link.Image {
+		kind = "Image"
+	}
+	where := fmt.Sprintf("%s:%d", rel, link.Line)
+	target := link.Target
+
+	if strings.HasPrefix(strings.ToLower(target), "file:") {
+		return []string{fmt.Sprintf("%s: %s '%s' points to a local file, which is not in the package.", where, kind, target)}, nil
+	}
+	if target == "" || urlSchemePattern.MatchString(target) || strings.HasPrefix(target, "//") {
+		return nil, nil
+	}
+
+	targetPath, fragment, _ := strings.Cut(target, "#")
+	targetPath, _, _ = strings.Cut(targetPath, "?")
+	if unescaped, err := url.PathUnescape(targetPath); err == nil {
+		targetPath = unescaped
+	}
+
+	if targetPath == "" {
+		if fragment != "" && !doc.Anchors()[fragment] {
+			warnings = append(warnings, fmt.Sprintf("%s: %s '%s' refers to a heading that does not exist.", where, kind, target))
+		}
+		return nil, warnings
+	}
+	if strings.HasPrefix(targetPath, "/") || filepath.IsAbs(targetPath) {
+		return []string{fmt.Sprintf("%s: %s '%s' is an absolute path; use a path relative to %s.", where, kind, target, rel)}, nil
+	}
+
+	resolved := filepath.Join(skillDir, filepath.Dir(filepath.FromSlash(rel)), filepath.FromSlash(targetPath))
+	within, err := filepath.Rel(skillDir, resolved)
+	if err != nil || within == ".." || strings.HasPrefix(within, ".."+string(filepath.Separator)) {
+		return []string{fmt.Sprintf("%s: %s '%s' points outside the skill directory, so it is not in the package.", where, kind, target)}, nil
+	}
+	if !fileExists(resolved) {
+		return []string{fmt.Sprintf("%s: %s '%s' is broken: %s does not exist.", where, kind, target, filepath.ToSlash(within))}, nil
+	}
+
+	if fragment != "" && strings.EqualFold(filepath.Ext(resolved), ".md") {
+		if targetDoc := parse(filepath.ToSlash(within)); targetDoc != nil && !targetDoc.Anchors()[fragment] {
+			warnings = append(warnings, fmt.Sprintf("%s: %s '%s' refers to a heading that does not exist in %s.", where, kind, target, filepath.ToSlash(within)))
+		}
+	}
+	return nil, warnings
+}
+
+// checkHeadings reports documents without a single level-1 title and
+// headings that skip levels.
+func checkHeadings(rel string, doc *markdown.Document) []string {
+	var warnings []string
+	if len(doc.Headings) == 0 {
+		return []string{fmt.Sprintf("%s has no headings; start it with a '# Title'.", rel)}
+	}
+	if first := doc.Headings[0]; first.Level != 1 {
+		warnings = append(warnings, fmt.Sprintf("%s:%d: the first heading should be a level-1 title ('# %s').", rel, first.Line, first.Text))
+	}
+
+	var titles []string
+	previous := 0
+	for _, h := range doc.Headings {
+		if h.Level == 1 {
+			titles = append(titles, fmt.Sprint(h.Line))
+		}
+		if h.Text == "" {
+			warnings = append(warnings, fmt.Sprintf("%s:%d: empty heading.", rel, h.Line))
+		}
+		if previous > 0 && h.Level > previous+1 {
+			warnings = append(warnings, fmt.Sprintf("%s:%d: heading '%s' skips from level %d to level %d.", rel, h.Line, h.Text, previous, h.Level))
+		}
+		previous = h.Level
+	}
+	if len(titles) > 1 {
+		warnings = append(warnings, fmt.Sprintf("%s has %d level-1 headings (lines %s); use one title and '##' for sections.", rel, len(titles), strings.Join(titles, ", ")))
+	}
+	return warnings
+}
+
+// checkPlaceholders reports scaffold text that was never replaced.
+func checkPlaceholders(rel string, doc *markdown.Document) []string {
+	var warnings []string
+	for i, line := range strings.Split(doc.Body, "\n") {
+		for _, pattern := range placeholderPatterns {
+			for _, loc := range pattern.FindAllStringIndex(line, -1) {
+				// "[Add ...](url)" is a link, not a placeholder
+				if loc[1] < len(line) && (line[loc[1]] == '(' || line[loc[1]] == '[') {
+					continue
+				}
+				warnings = append(warnings, fmt.Sprintf("%s:%d: replace the placeholder '%s'.", rel, doc.BodyLine+i, line[loc[0]:loc[1]]))
+			}
+		}
+	}
+	return warnings
+}
+
+// skillTokenEstimate returns the estimated size of SKILL.md in tokens, or -1
+// if the skill has no SKILL.md.
+func skillTokenEstimate(skillDir string) int {
+	content, err := os.ReadFile(filepath.Join(skillDir, "SKILL.md"))
+	if err != nil {
+		return -1
+	}
+	return markdown.EstimateTokens(markdown.Parse(string(content)).Body)
+}
@@ -2,9 +2,11 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/skilzy/skilzy-cli/utils"
 	"github.com/spf13/cobra"
@@ -18,27 +20,52 @@ var meCmd = &cobra.Command{
 
 var meWhoamiCmd = &cobra.Command{
 	Use:   "whoami",
-	Short: "Validate the currently configured API key",
-	Long:  `Validates your saved API key by testing it against the Skilzy API.`,
+	Short: "Show the account behind the currently configured API key",
+	Long: `Validates your API key against the Skilzy API and shows the account,
+organizations, scopes and expiry it belongs to. Exits with an error if the
+key cannot be used to publish skills.
+
+The CLI has no named profiles. The active profile is the config file in
+~/.skilzy, the credential store it selects and the registry, and all three are
+shown.`,
 	 Run:   runMeWhoami,
 }
 
+var (
+	meSkillsStatus string
+	meSkillsOrg    string
+	meSkillsOutput string
+)
+
 var meSkillsCmd = &cobra.Command{
-	Use:   "skills",
+	Use:   "skills [name]",
 	Short: "List all skills you have published",
-	Long:  `Shows all skills you've published to the registry with their version and review status.`,
-	Run:   runMeSkills,
+	Long: `Shows all skills you've published to the registry with their version and review status.
+
+With a skill name, lists every version of that skill with its review status,
+review notes and dates.
+
+Examples:
+  skilzy me skills
+  skilzy me skills my-skill
+  skilzy me skills my-skill --status rejected
+  skilzy me skills my-skill --output json`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runMeSkills,
 }
 
 func init() {
 	rootCmd.AddCommand(meCmd)
 	meCmd.AddCommand(meWhoamiCmd)
 	meCmd.AddCommand(meSkillsCmd)
+	meSkillsCmd.Flags().StringVar(&meSkillsStatus, "status", "", "Only show versions with this status (e.g. pending_review, published, rejected)")
+	meSkillsCmd.Flags().StringVar(&meSkillsOrg, "org", "", "List versions of a skill published under an organization")
+	meSkillsCmd.Flags().StringVarP(&meSkillsOutput, "output", "o", "table", "Output format: table or json")
 }
 
 func runMeWhoami(cmd *cobra.Command, args []string) {
 	// Load API key
-	apiKey, err := utils.LoadAPIKey()
+	apiKey, source, err := utils.ResolveAPIKey()
 	if err != nil {
 		fmt.Printf("✗ Failed to load API key: %v\n", err)
 		os.Exit(1)
@@ -56,12 +83,24 @@ func runMeWhoami(cmd *cobra.Command, args []string) {
 		keyPrefix = apiKey[:8] + "..."
 	}
 	fmt.Printf("Loaded API key prefix: %s\n", keyPrefix)
+	fmt.Printf("Key source: %s\n", describeAPIKeySource(source))
+	fmt.Printf("Precedence: %s environment variable > credential store\n", utils.APIKeyEnvVar)
+
+	if configPath, err := utils.GetConfigPath(); err == nil {
+		fmt.Printf("Config file: %s\n", configPath)
+	}
+	if store, err := utils.GetCredentialStore(); err != nil {
+		fmt.Printf("Credential store: unavailable (%v)\n", err)
+	} else {
+		fmt.Printf("Credential store: %s\n", store.Describe())
+	}
+	fmt.Printf("Registry: %s (%s)\n", utils.GetRegistryURL(), describeRegistrySource())
 
 	// Validate with API
 	fmt.Println("Attempting to validate key with the API...")
 
 	client := utils.NewSkilzyClient(apiKey)
-	_, err = client.GetMySkills()
+	me, err := client.GetMe()
 	if err != nil {
 		if strings.Contains(err.Error(), "authentication failed") {
 			fmt.Println("\n✗ Validation failed: The API rejected this key (401 Unauthorized).")
@@ -73,9 +112,81 @@ func runMeWhoami(cmd *cobra.Command, args []string) {
 	}
 
 	fmt.Println("\n✓ Validation successful: The API accepted this key.")
+	fmt.Printf("\n  Username: %s\n", me.Username)
+	if me.Email != "" {
+		fmt.Printf("  Email: %s\n", me.Email)
+	}
+
+	if len(me.Organizations) > 0 {
+		fmt.Println("  Organizations:")
+		for _, org := range me.Organizations {
+			fmt.Printf("    - %s (%s)\n", org.Name, org.Role)
+		}
+	} else {
+		fmt.Println("  Organizations: none")
+	}
+
+	if me.Key == nil {
+		fmt.Println("  Key scopes: unknown")
+		fmt.Printf("\n✗ The registry did not report this key's scopes, so its '%s' access could not be verified.\n", utils.ScopePublish)
+		os.Exit(1)
+	}
+
+	if me.Key.Name != "" {
+		fmt.Printf("  Key name: %s\n", me.Key.Name)
+	}
+	fmt.Printf("  Key scopes: %s\n", strings.Join(me.Key.Scopes, ", "))
+	if me.Key.ExpiresAt != nil {
+		expiry := me.Key.ExpiresAt.Local().Format(time.RFC1123)
+		if time.Until(*me.Key.ExpiresAt) < 7*24*time.Hour {
+			fmt.Printf("  Key expires: %s ⚠️  expires soon\n", expiry)
+		} else {
+			fmt.Printf("  Key expires: %s\n", expiry)
+		}
+	} else {
+		fmt.Println("  Key expires: never")
+	}
+
+	if !me.Key.HasScope(utils.ScopePublish) {
+		fmt.Printf("\n✗ This key does not have the '%s' scope and cannot be used to publish skills.\n", utils.ScopePublish)
+		fmt.Println("  Create a key with publish access and run 'skilzy login' again.")
+		os.Exit(1)
+	}
+}
+
+// describeAPIKeySource returns a human-readable description of where the key was loaded from.
+func describeAPIKeySource(source utils.APIKeySource) string {
+	switch source {
+	case utils.APIKeySourceEnv:
+		return utils.APIKeyEnvVar + " environment variable"
+	case utils.APIKeySourceStore:
+		store, err := utils.GetCredentialStore()
+		if err != nil {
+			return "credential store"
+		}
+		return store.Describe()
+	default:
+		return "none"
+	}
+}
+
+// describeRegistrySource says where GetRegistryURL took the registry from.
+func describeRegistrySource() string {
+	if strings.TrimSpace(os.Getenv(utils.RegistryEnvVar)) != "" {
+		return utils.RegistryEnvVar + " environment variable"
+	}
+	if config, err := utils.LoadConfig(); err == nil && config.Registry != "" {
+		return "config file"
+	}
+	return "default"
 }
 
 func runMeSkills(cmd *cobra.Command, args []string) {
+	if meSkillsOutput != "table" && meSkillsOutput != "json" {
+		fmt.Printf("✗ Invalid output format '%s' (expected table or json)\n", meSkillsOutput)
+		os.Exit(1)
+	}
+
 	// Load API key
 	apiKey, err := utils.LoadAPIKey()
 	if err != nil {
@@ -89,22 +200,128 @@ func runMeSkills(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	// Get published skills
 	client := utils.NewSkilzyClient(apiKey)
+	if len(args) == 1 {
+		runMeSkillVersions(client, args[0])
+		return
+	}
+
+	// Get published skills
 	skills, err := client.GetMySkills()
 	if err != nil {
 		fmt.Printf("✗ Failed to retrieve skills: %v\n", err)
 		os.Exit(1)
 	}
 
+	if meSkillsStatus != "" {
+		filtered := []utils.MySkill{}
+		for _, skill := range skills {
+			if skill.LatestVersion != nil && skill.LatestVersion.Status == meSkillsStatus {
+				filtered = append(filtered, skill)
+			}
+		}
+		skills = filtered
+	}
+
+	if meSkillsOutput == "json" {
+		// An empty list is printed as [], not null
+		if skills == nil {
+			skills = []utils.MySkill{}
+		}
+		printJSON(skills)
+		return
+	}
+
 	// Display results
 	if len(skills) == 0 {
+		if meSkillsStatus != "" {
+			fmt.Printf("You have no skills whose latest version is '%s'.\n", meSkillsStatus)
+			return
+		}
 		fmt.Println("You have not published any skills yet.")
 		return
 	}
 
 	fmt.Printf("You have published %d skill(s):\n\n", len(skills))
+	printSkillsTable(skills)
+}
+
+// runMeSkillVersions lists every version of one skill.
+func runMeSkillVersions(client *utils.SkilzyClient, name string) {
+	versions, err := client.GetAllSkillVersions(meSkillsOrg, name, meSkillsStatus)
+	if err != nil {
+		fmt.Printf("✗ Failed to retrieve versions of '%s': %v\n", name, err)
+		os.Exit(1)
+	}
+
+	// The status filter is also applied locally in case the registry ignores it
+	if meSkillsStatus != "" {
+		filtered := []utils.SkillVersion{}
+		for _, v := range versions {
+			if v.Status == meSkillsStatus {
+				filtered = append(filtered, v)
+			}
+		}
+		versions = filtered
+	}
+
+	if meSkillsOutput == "json" {
+		if versions == nil {
+			versions = []utils.SkillVersion{}
+		}
+		printJSON(versions)
+		return
+	}
+
+	if len(versions) == 0 {
+		fmt.Printf("No versions of '%s' found.\n", name)
+		return
+	}
+
+	fmt.Printf("%s has %d version(s):\n\n", name, len(versions))
+
+	// Print table header
+	fmt.Printf("%-15s %-20s %-12s %s\n", "VERSION", "STATUS", "SUBMITTED", "REVIEWED")
+	fmt.Println(strings.Repeat("-", 60))
+
+	// Print results
+	for _, v := range versions {
+		submitted, reviewed := "-", "-"
+		if v.CreatedAt != nil {
+			submitted = v.CreatedAt.Local().Format("2006-01-02")
+		}
+		if v.ReviewedAt != nil {
+			reviewed = v.ReviewedAt.Local().Format("2006-01-02")
+		}
+		status := v.Status
+		if v.Yanked {
+			status += " (yanked)"
+		}
+
+		fmt.Printf("%-15s %-20s %-12s %s\n", v.Version, status, submitted, reviewed)
+		if v.Deprecated != "" {
+			fmt.Printf("  ⚠️  deprecated: %s\n", v.Deprecated)
+		}
+		for _, line := range strings.Split(strings.TrimSpace(v.ReviewNotes), "\n") {
+			if line != "" {
+				fmt.Printf("  📝 %s\n", line)
+			}
+		}
+	}
+}
+
+// printJSON writes a value to stdout as indented JSON.
+func printJSON(value interface{}) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		fmt.Printf("✗ Failed to encode JSON: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
 
+// printSkillsTable prints a table of skills with their latest version and review status.
+func printSkillsTable(skills []utils.MySkill) {
 	// Print table header
 	fmt.Printf("%-30s %-15s %-20s %s\n", "NAME", "LATEST VERSION", "STATUS", "PUBLISHED/TOTAL VERSIONS")
 	fmt.Println(strings.Repeat("-", 90))
@@ -126,5 +343,8 @@ func runMeSkills(cmd *cobra.Command, args []string) {
 		versionInfo := fmt.Sprintf("%d/%d", skill.PublishedVersionCount, skill.TotalVersions)
 
 		fmt.Printf("%-30s %-15s %-20s %s\n", name, version, status, versionInfo)
+		if skill.Deprecated != "" {
+			fmt.Printf("  ⚠️  deprecated: %s\n", skill.Deprecated)
+		}
 	}
 }
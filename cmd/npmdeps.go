@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/skilzy/skilzy-cli/semver"
+)
+
+// npmRequirement is a parsed dependencies.npm entry such as "@scope/pkg@^1.2".
+type npmRequirement struct {
+	Name  string
+	Range string // empty if unpinned
+}
+
+var npmNamePattern = regexp.MustCompile(`^(?:@[a-z0-9-~][a-z0-9-._~]*/)?[a-z0-9-~][a-z0-9-._~]*$`)
+
+// parseNpmRequirement parses "<name>[@<range>]", where the range is an npm-style SemVer range.
+func parseNpmRequirement(s string) (npmRequirement, error) {
+	var req npmRequirement
+	s = strings.TrimSpace(s)
+	// A scoped name starts with '@', so the version separator is the last one
+	name, rng := s, ""
+	if i := strings.LastIndex(s, "@"); i > 0 {
+		name, rng = s[:i], strings.TrimSpace(s[i+1:])
+		if rng == "" {
+			return req, fmt.Errorf("missing version range after '@'")
+		}
+	}
+	if len(name) > 214 || !npmNamePattern.MatchString(name) {
+		return req, fmt.Errorf("'%s' is not a valid npm package name", name)
+	}
+	if rng != "" {
+		if _, err := semver.ParseRange(rng); err != nil {
+			return req, fmt.Errorf("invalid version range: %v", err)
+		}
+	}
+	return npmRequirement{Name: name, Range: rng}, nil
+}
+
+// performNpmDependencyChecks parses the manifest's dependencies.npm entries,
+// compares them with package.json or deno.json, and looks for imports in
+// scripts/ that no dependency provides. Problems that make the manifest wrong
+// are returned as errors; the rest as warnings.
+func performNpmDependencyChecks(skillDir string, manifestContent []byte) (errors, warnings []string) {
+	var data struct {
+		Dependencies struct {
+			Npm []string `json:"npm"`
+		} `json:"dependencies"`
+	}
+	json.Unmarshal(manifestContent, &data)
+
+	declared := map[string]npmRequirement{}
+	for _, entry := range data.Dependencies.Npm {
+		req, err := parseNpmRequirement(entry)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("dependencies.npm: '%s' must have the form '<package>[@<range>]': %v.", entry, err))
+			continue
+		}
+		if _, dup := declared[req.Name]; dup {
+			errors = append(errors, fmt.Sprintf("dependencies.npm: '%s' is declared more than once.", req.Name))
+		}
+		declared[req.Name] = req
+		if req.Range == "" {
+			warnings = append(warnings, fmt.Sprintf("dependencies.npm: '%s' is unpinned; add a version range such as '^' or '~'.", entry))
+		}
+	}
+
+	// Cross-check the project's own dependency files
+	for _, source := range []struct {
+		file string
+		read func(string) (map[string]string, error)
+	}{
+		{"package.json", readPackageJSONDependencies},
+		{"deno.json", readDenoJSONDependencies},
+	} {
+		listed, err := source.read(filepath.Join(skillDir, source.file))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Could not read %s: %v.", source.file, err))
+			continue
+		}
+		for name, rng := range listed {
+			manifestReq, ok := declared[name]
+			if !ok {
+				errors = append(errors, fmt.Sprintf("'%s' is listed in %s but missing from dependencies.npm.", name, source.file))
+			} else if rng != manifestReq.Range {
+				warnings = append(warnings, fmt.Sprintf("'%s' has version %s in %s but %s in dependencies.npm.", name, describeNpmRange(rng), source.file, describeNpmRange(manifestReq.Range)))
+			}
+		}
+		for name := range declared {
+			if _, ok := listed[name]; !ok {
+				warnings = append(warnings, fmt.Sprintf("'%s' is in dependencies.npm but not in %s.", name, source.file))
+			}
+		}
+	}
+
+	// Imports in bundled scripts
+	for _, imp := range undeclaredNpmImports(skillDir, declared) {
+		warnings = append(warnings, fmt.Sprintf("%s imports '%s', which is not provided by any entry in dependencies.npm.", imp.location, imp.module))
+	}
+
+	sort.Strings(errors)
+	sort.Strings(warnings)
+	return errors, warnings
+}
+
+// describeNpmRange quotes a version range for messages.
+func describeNpmRange(rng string) string {
+	if rng == "" {
+		return "no range"
+	}
+	return "'" + rng + "'"
+}
+
+// readPackageJSONDependencies returns the runtime dependencies of a package.json
+// and their ranges. Development dependencies are not shipped, so they are ignored.
+func readPackageJSONDependencies(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pkg struct {
+		Dependencies map[string]string `json:"dependencies"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, err
+	}
+	deps := map[string]string{}
+	for name, rng := range pkg.Dependencies {
+		if rng == "*" || rng == "latest" {
+			rng = ""
+		}
+		deps[name] = rng
+	}
+	return deps, nil
+}
+
+// readDenoJSONDependencies returns the "npm:" entries of a deno.json import map.
+func readDenoJSONDependencies(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config struct {
+		Imports map[string]string `json:"imports"`
+	}
+	if err := json.Unmarshal(content, &config); err != nil {
+		return nil, err
+	}
+	deps := map[string]string{}
+	for _, target := range config.Imports {
+		spec, ok := strings.CutPrefix(target, "npm:")
+		if !ok {
+			continue
+		}
+		if req, err := parseNpmRequirement(strings.TrimSuffix(spec, "/")); err == nil {
+			deps[req.Name] = req.Range
+		}
+	}
+	return deps, nil
+}
+
+var (
+	jsImportPattern  = regexp.MustCompile(`(?:^|[^\w.$])(?:import|export)\s[^'"]*?from\s*['"]([^'"]+)['"]|(?:^|[^\w.$])import\s*\(?\s*['"]([^'"]+)['"]`)
+	jsRequirePattern = regexp.MustCompile(`(?:^|[^\w.$])require\s*\(\s*['"]([^'"]+)['"]\s*\)`)
+)
+
+// jsScriptExtensions are the files scanned for imports.
+var jsScriptExtensions = map[string]bool{".js": true, ".mjs": true, ".cjs": true, ".ts": true, ".mts": true, ".cts": true, ".jsx": true, ".tsx": true}
+
+// undeclaredNpmImports returns the packages imported by the JavaScript and
+// TypeScript files in scripts/ that are neither built in, relative, nor declared.
+func undeclaredNpmImports(skillDir string, declared map[string]npmRequirement) []scriptImport {
+	var imports []scriptImport
+	reported := map[string]bool{}
+	scriptsDir := filepath.Join(skillDir, "scripts")
+	filepath.Walk(scriptsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if isIgnoredAuditDir(info.Name()) && path != scriptsDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !jsScriptExtensions[filepath.Ext(path)] {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(skillDir, path)
+		for i, line := range strings.Split(string(content), "\n") {
+			for _, specifier := range importedPackages(line) {
+				name, ok := npmPackageName(specifier)
+				if !ok || reported[name] {
+					continue
+				}
+				if _, ok := declared[name]; ok {
+					continue
+				}
+				reported[name] = true
+				imports = append(imports, scriptImport{location: fmt.Sprintf("%s:%d", filepath.ToSlash(rel), i+1), module: name})
+			}
+		}
+		return nil
+	})
+	return imports
+}
+
+// importedPackages returns the module specifiers imported or required on a line.
+func importedPackages(line string) []string {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "//") || strings.HasPrefix(trimmed, "*") {
+		return nil
+	}
+	var specifiers []string
+	for _, m := range jsImportPattern.FindAllStringSubmatch(line, -1) {
+		specifiers = append(specifiers, m[1]+m[2])
+	}
+	for _, m := range jsRequirePattern.FindAllStringSubmatch(line, -1) {
+		specifiers = append(specifiers, m[1])
+	}
+	return specifiers
+}
+
+// npmPackageName returns the npm package a module specifier refers to, or
+// false for relative paths, URLs, JSR packages and built-in modules.
+func npmPackageName(specifier string) (string, bool) {
+	if spec, ok := strings.CutPrefix(specifier, "npm:"); ok {
+		if req, err := parseNpmRequirement(packageRoot(spec)); err == nil {
+			return req.Name, true
+		}
+		return "", false
+	}
+	if strings.HasPrefix(specifier, ".") || strings.HasPrefix(specifier, "/") || strings.Contains(specifier, ":") {
+		return "", false
+	}
+	name := packageRoot(specifier)
+	if nodeBuiltinModules[name] || !npmNamePattern.MatchString(name) {
+		return "", false
+	}
+	return name, true
+}
+
+// packageRoot strips the subpath from a specifier: "@scope/pkg/sub" becomes
+// "@scope/pkg" and "pkg/sub" becomes "pkg". A version suffix is kept.
+func packageRoot(specifier string) string {
+	parts := strings.SplitN(specifier, "/", 3)
+	if strings.HasPrefix(specifier, "@") && len(parts) >= 2 {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
+}
+
+// nodeBuiltinModules lists the modules built into Node.js, which may also be
+// imported with a "node:" prefix.
+var nodeBuiltinModules = func() map[string]bool {
+	modules := map[string]bool{}
+	for _, name := range strings.Fields(`
+		assert async_hooks buffer child_process cluster console constants crypto
+		dgram diagnostics_channel dns domain events fs http http2 https inspector
+		module net os path perf_hooks process punycode querystring readline repl
+		stream string_decoder sys timers tls trace_events tty url util v8 vm wasi
+		worker_threads zlib`) {
+		modules[name] = true
+	}
+	return modules
+}()
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var orgCmd = &cobra.Command{
+	Use:   "org",
+	Short: "Manage the organizations you belong to",
+	Long:  `Commands for listing your organizations and the skills published under their namespace.`,
+}
+
+var orgListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the organizations you are a member of",
+	Long:  `Shows every organization you belong to along with your role in it.`,
+	Args:  cobra.NoArgs,
+	Run:   runOrgList,
+}
+
+var orgSkillsCmd = &cobra.Command{
+	Use:   "skills <org>",
+	Short: "List the skills published under an organization",
+	Long: `Shows all skills published under an organization's namespace with their
+version and review status. Publish to an organization with 'skilzy publish --org <org>'.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runOrgSkills,
+}
+
+func init() {
+	rootCmd.AddCommand(orgCmd)
+	orgCmd.AddCommand(orgListCmd)
+	orgCmd.AddCommand(orgSkillsCmd)
+}
+
+func runOrgList(cmd *cobra.Command, args []string) {
+	client := newAuthenticatedClient()
+
+	orgs, err := client.GetMyOrganizations()
+	if err != nil {
+		fmt.Printf("✗ Failed to retrieve organizations: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(orgs) == 0 {
+		fmt.Println("You are not a member of any organizations.")
+		return
+	}
+
+	fmt.Printf("You are a member of %d organization(s):\n\n", len(orgs))
+
+	// Print table header
+	fmt.Printf("%-30s %-15s %-10s %s\n", "NAME", "ROLE", "MEMBERS", "SKILLS")
+	fmt.Println(strings.Repeat("-", 70))
+
+	// Print results
+	for _, org := range orgs {
+		name := org.Name
+		if len(name) > 30 {
+			name = name[:27] + "..."
+		}
+		fmt.Printf("%-30s %-15s %-10d %d\n", name, org.Role, org.MemberCount, org.SkillCount)
+	}
+}
+
+func runOrgSkills(cmd *cobra.Command, args []string) {
+	orgName := args[0]
+	client := newAuthenticatedClient()
+
+	// Look up the caller's role so it can be shown alongside the skills
+	role := ""
+	orgs, membershipErr := client.GetMyOrganizations()
+	if membershipErr == nil {
+		for _, org := range orgs {
+			if org.Name == orgName {
+				role = org.Role
+				break
+			}
+		}
+	}
+
+	skills, err := client.GetOrgSkills(orgName)
+	if err != nil {
+		fmt.Printf("✗ Failed to retrieve skills for organization '%s': %v\n", orgName, err)
+		os.Exit(1)
+	}
+
+	switch {
+	case membershipErr != nil:
+		fmt.Printf("Organization: %s (your membership could not be determined: %v)\n\n", orgName, membershipErr)
+	case role != "":
+		fmt.Printf("Organization: %s (your role: %s)\n\n", orgName, role)
+	default:
+		fmt.Printf("Organization: %s (you are not a member)\n\n", orgName)
+	}
+
+	if len(skills) == 0 {
+		fmt.Println("No skills have been published under this organization yet.")
+		return
+	}
+
+	fmt.Printf("%d skill(s) published under %s:\n\n", len(skills), orgName)
+	printSkillsTable(skills)
+}
@@ -8,19 +8,26 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
 var outputDir string
 var outputName string
+var packageAllowSecrets bool
 
 var packageCmd = &cobra.Command{
 	Use:   "package",
 	Short: "Validate and package a skill into a distributable .skill file",
 	Long: `This command first validates the skill in the current directory.
 If the skill is valid, it bundles all its files into a compressed .skill archive
-containing a single root folder, ready for distribution.`,
+containing a single root folder, ready for distribution.
+
+Skills listed in a skilzy-workspace.json write their packages to the
+workspace's output directory. With --workspace, or when run in the workspace
+root, every member is validated and packaged, dependencies first; --changed
+<ref> limits this to skills with changes since the git ref.`,
 	Run:  runPackage,
 	Args: cobra.NoArgs,
 }
@@ -29,15 +36,32 @@ func init() {
 	rootCmd.AddCommand(packageCmd)
 	packageCmd.Flags().StringVarP(&outputDir, "output-dir", "o", "dist", "Directory to save the packaged skill (relative to the project root)")
 	packageCmd.Flags().StringVar(&outputName, "output-name", "", "Specify a custom name for the output .skill file")
+	packageCmd.Flags().BoolVar(&packageAllowSecrets, "allow-secrets", false, "Keep the package even if the secret scan finds something")
+	addWorkspaceFlags(packageCmd)
 }
 
 func runPackage(cmd *cobra.Command, args []string) {
+	if usingWorkspace(nil) {
+		runWorkspacePackage(cmd)
+		return
+	}
+
 	skillDir, err := os.Getwd()
 	if err != nil {
 		fmt.Printf("❌ Error getting current directory: %v\n", err)
 		os.Exit(1)
 	}
 
+	// Skills in a workspace share its output directory
+	projectRoot := filepath.Dir(skillDir)
+	finalOutputDir := filepath.Join(projectRoot, outputDir)
+	if ws := memberWorkspace(skillDir); ws != nil {
+		finalOutputDir = ws.OutputPath()
+		if cmd.Flags().Changed("output-dir") {
+			finalOutputDir = filepath.Join(ws.Root, outputDir)
+		}
+	}
+
 	fmt.Println("📦 Starting package process...")
 	validationErrors := doValidation(skillDir)
 	if len(validationErrors) > 0 {
@@ -47,52 +71,97 @@ func runPackage(cmd *cobra.Command, args []string) {
 	}
 	fmt.Println("✨ Skill is valid, proceeding with packaging.")
 
-	manifestPath := filepath.Join(skillDir, "skill.json")
-	content, _ := os.ReadFile(manifestPath)
-	var data struct {
-		Name    string `json:"name"`
-		Version string `json:"version"`
+	archivePath, err := packageSkill(skillDir, finalOutputDir, outputName)
+	if err != nil {
+		fmt.Printf("\n❌ %v\n", err)
+		os.Exit(1)
 	}
-	json.Unmarshal(content, &data)
+	fmt.Printf("\n✅ Successfully packaged skill to: %s\n", archivePath)
+}
 
-	projectRoot := filepath.Dir(skillDir)
-	finalOutputDir := filepath.Join(projectRoot, outputDir)
-	if err := os.MkdirAll(finalOutputDir, 0755); err != nil {
-		fmt.Printf("❌ Failed to create output directory %s: %v\n", finalOutputDir, err)
-		os.Exit(1)
+// packageSkill packages a validated skill into outputDir, named archiveName
+// or after the skill's name and version, and scans the package for secrets.
+// It returns the path of the package.
+func packageSkill(skillDir, outputDir, archiveName string) (string, error) {
+	data, err := readManifestInfo(skillDir)
+	if err != nil {
+		return "", err
 	}
 
-	archiveFileName := outputName
-	if archiveFileName == "" {
-		archiveFileName = fmt.Sprintf("%s-%s.skill", data.Name, data.Version)
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory %s: %w", outputDir, err)
 	}
-	archivePath := filepath.Join(finalOutputDir, archiveFileName)
-	
+
+	if archiveName == "" {
+		archiveName = data.archiveName()
+	}
+	archivePath := filepath.Join(outputDir, archiveName)
+
+	if err := createPackage(skillDir, data, archivePath, outputDir); err != nil {
+		return "", err
+	}
+
+	if !checkPackageSecrets(archivePath, packageAllowSecrets) {
+		os.Remove(archivePath)
+		return "", fmt.Errorf("packaging aborted; the package was removed")
+	}
+	return archivePath, nil
+}
+
+// manifestInfo holds the manifest fields needed to name and publish a package.
+type manifestInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// archiveName returns the default file name for the skill's package.
+func (m manifestInfo) archiveName() string {
+	return fmt.Sprintf("%s-%s.skill", m.Name, m.Version)
+}
+
+// readManifestInfo reads the name and version from the skill.json in skillDir.
+func readManifestInfo(skillDir string) (manifestInfo, error) {
+	var data manifestInfo
+	content, err := os.ReadFile(filepath.Join(skillDir, "skill.json"))
+	if err != nil {
+		return data, fmt.Errorf("failed to read skill.json: %w", err)
+	}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return data, fmt.Errorf("failed to parse skill.json: %w", err)
+	}
+	return data, nil
+}
+
+// createPackage bundles every file in skillDir into a compressed archive at
+// archivePath, under a single root folder named after the skill. excludeDir,
+// if it lies within skillDir, is left out of the archive. If CHANGELOG.md has a
+// section for the skill's version, it is added as RELEASE_NOTES.md.
+func createPackage(skillDir string, data manifestInfo, archivePath, excludeDir string) error {
+	skillName := data.Name
+
 	archiveFile, err := os.Create(archivePath)
 	if err != nil {
-		fmt.Printf("❌ Failed to create archive file: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to create archive file: %w", err)
 	}
 	defer archiveFile.Close()
 
 	zipWriter := zip.NewWriter(archiveFile)
-	defer zipWriter.Close()
 
 	err = filepath.Walk(skillDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil { return err }
 
-		if info.IsDir() && path == finalOutputDir {
+		if info.IsDir() && path == excludeDir {
 			return filepath.SkipDir
 		}
 		
-		if path == skillDir { return nil }
+		if path == skillDir || path == archivePath { return nil }
 
 		header, err := zip.FileInfoHeader(info)
 		if err != nil { return err }
 		
 		relativePath, err := filepath.Rel(skillDir, path)
 		if err != nil { return err }
-		header.Name = filepath.Join(data.Name, relativePath)
+		header.Name = filepath.Join(skillName, relativePath)
 		header.Name = filepath.ToSlash(header.Name)
 
 		if info.IsDir() {
@@ -115,9 +184,26 @@ func runPackage(cmd *cobra.Command, args []string) {
 	})
 
 	if err != nil {
-		fmt.Printf("❌ Failed to add files to archive: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to add files to archive: %w", err)
 	}
 
-	fmt.Printf("\n✅ Successfully packaged skill to: %s\n", archivePath)
+	// Include the current release notes so the registry can display them
+	if _, err := os.Stat(filepath.Join(skillDir, "RELEASE_NOTES.md")); os.IsNotExist(err) {
+		if notes := currentReleaseNotes(skillDir, data.Version); notes != "" {
+			header := &zip.FileHeader{Name: skillName + "/RELEASE_NOTES.md", Method: zip.Deflate, Modified: time.Now()}
+			writer, err := zipWriter.CreateHeader(header)
+			if err != nil {
+				return fmt.Errorf("failed to add release notes to archive: %w", err)
+			}
+			if _, err := io.WriteString(writer, notes+"\n"); err != nil {
+				return fmt.Errorf("failed to add release notes to archive: %w", err)
+			}
+			fmt.Printf("📝 Included release notes for %s\n", data.Version)
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	return nil
 }
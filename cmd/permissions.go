@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/skilzy/skilzy-cli/scaffold"
+)
+
+// askPermissions prompts for the network and filesystem access a skill needs.
+// It returns nil if the skill needs neither.
+func askPermissions() (*scaffold.Permissions, error) {
+	permissions := &scaffold.Permissions{}
+
+	needsNetwork := false
+	if err := survey.AskOne(&survey.Confirm{Message: "Does the skill need network access?", Default: false}, &needsNetwork); err != nil {
+		return nil, err
+	}
+	if needsNetwork {
+		answers := struct{ Hosts, Description string }{}
+		qs := []*survey.Question{
+			{Name: "hosts", Prompt: &survey.Input{Message: "Allowed hosts (comma-separated):", Help: "Hostnames the skill contacts, e.g. api.example.com or *.example.org."}, Validate: validateHostList},
+			{Name: "description", Prompt: &survey.Input{Message: "Why does the skill need network access?"}, Validate: survey.Required},
+		}
+		if err := survey.Ask(qs, &answers); err != nil {
+			return nil, err
+		}
+		permissions.Network = &scaffold.NetworkPermission{
+			AllowedHosts: scaffold.SplitList(answers.Hosts),
+			Description:  answers.Description,
+		}
+	}
+
+	access := scaffold.FilesystemAccessNone
+	accessOptions := []string{scaffold.FilesystemAccessNone, scaffold.FilesystemAccessRead, scaffold.FilesystemAccessWrite, scaffold.FilesystemAccessReadWrite}
+	if err := survey.AskOne(&survey.Select{Message: "Filesystem access:", Options: accessOptions, Default: access}, &access); err != nil {
+		return nil, err
+	}
+	if access != scaffold.FilesystemAccessNone {
+		answers := struct{ Paths, Description string }{}
+		qs := []*survey.Question{
+			{Name: "paths", Prompt: &survey.Input{Message: "Paths (comma-separated, relative to the skill, optional):"}, Validate: validatePathList},
+			{Name: "description", Prompt: &survey.Input{Message: "Why does the skill need filesystem access?"}, Validate: survey.Required},
+		}
+		if err := survey.Ask(qs, &answers); err != nil {
+			return nil, err
+		}
+		permissions.Filesystem = &scaffold.FilesystemPermission{
+			Access:      access,
+			Paths:       scaffold.SplitList(answers.Paths),
+			Description: answers.Description,
+		}
+	}
+
+	if permissions.Network == nil && permissions.Filesystem == nil {
+		return nil, nil
+	}
+	return permissions, nil
+}
+
+func validateHostList(val interface{}) error {
+	hosts := scaffold.SplitList(val.(string))
+	if len(hosts) == 0 {
+		return fmt.Errorf("at least one host is required")
+	}
+	for _, host := range hosts {
+		if err := validateHostPattern(host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validatePathList(val interface{}) error {
+	for _, path := range scaffold.SplitList(val.(string)) {
+		if err := validatePermissionPath(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var hostLabelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// validateHostPattern checks that host is a hostname, an IP address or a
+// "*.example.com" wildcard, optionally followed by a port.
+func validateHostPattern(host string) error {
+	if strings.Contains(host, "://") || strings.Contains(host, "/") {
+		return fmt.Errorf("'%s' must be a hostname, not a URL", host)
+	}
+
+	name := host
+	if h, port, err := net.SplitHostPort(host); err == nil {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("'%s' has an invalid port", host)
+		}
+		name = h
+	}
+	if net.ParseIP(strings.Trim(name, "[]")) != nil {
+		return nil
+	}
+
+	if name == "*" {
+		return fmt.Errorf("'*' allows every host; list the hosts the skill contacts or use a wildcard such as '*.example.com'")
+	}
+	name = strings.TrimPrefix(name, "*.")
+	if len(name) > 253 {
+		return fmt.Errorf("'%s' is longer than 253 characters", host)
+	}
+	labels := strings.Split(name, ".")
+	for _, label := range labels {
+		if label == "*" {
+			return fmt.Errorf("'%s' may only use a wildcard as its first label, e.g. '*.example.com'", host)
+		}
+		if !hostLabelPattern.MatchString(label) {
+			return fmt.Errorf("'%s' is not a valid lowercase hostname", host)
+		}
+	}
+	if strings.HasPrefix(host, "*.") && len(labels) < 2 {
+		return fmt.Errorf("'%s' is too broad; wildcards must cover a domain, e.g. '*.example.com'", host)
+	}
+	return nil
+}
+
+// validatePermissionPath checks that path is relative and stays inside the skill directory.
+func validatePermissionPath(path string) error {
+	if filepath.IsAbs(path) || strings.HasPrefix(path, "/") || strings.HasPrefix(path, `\`) || filepath.VolumeName(path) != "" {
+		return fmt.Errorf("'%s' must be relative to the skill directory", path)
+	}
+	if strings.HasPrefix(path, "~") {
+		return fmt.Errorf("'%s' must be relative to the skill directory, not the home directory", path)
+	}
+	clean := filepath.ToSlash(filepath.Clean(path))
+	if clean == ".." || strings.HasPrefix(clean, "../") {
+		return fmt.Errorf("'%s' points outside the skill directory", path)
+	}
+	return nil
+}
+
+// performPermissionChecks ensures the hosts and paths declared in the manifest's
+// permissions are well-formed.
+func performPermissionChecks(manifestContent []byte) []string {
+	var errors []string
+	var data struct {
+		Permissions scaffold.Permissions `json:"permissions"`
+	}
+	json.Unmarshal(manifestContent, &data)
+
+	if network := data.Permissions.Network; network != nil {
+		for _, host := range network.AllowedHosts {
+			if err := validateHostPattern(host); err != nil {
+				errors = append(errors, fmt.Sprintf("permissions.network.allowedHosts: %v.", err))
+			}
+		}
+	}
+
+	if fs := data.Permissions.Filesystem; fs != nil {
+		if fs.Access == scaffold.FilesystemAccessNone && len(fs.Paths) > 0 {
+			errors = append(errors, "permissions.filesystem.paths is set but access is 'none'.")
+		}
+		for _, path := range fs.Paths {
+			if err := validatePermissionPath(path); err != nil {
+				errors = append(errors, fmt.Sprintf("permissions.filesystem.paths: %v.", err))
+			}
+		}
+	}
+	return errors
+}
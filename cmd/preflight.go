@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/skilzy/skilzy-cli/utils"
+)
+
+// maxPackageSize mirrors the registry's upload limit for local policy emulation.
+const maxPackageSize = 50 * 1024 * 1024
+
+// runPreflight prints a report of what publishing packagePath would do, without
+// uploading it. It reports whether the registry would accept the package.
+func runPreflight(client *utils.SkilzyClient, packagePath string) bool {
+	ok := true
+
+	manifestContent, err := utils.ExtractManifestFromZip(packagePath)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return false
+	}
+	var data manifestInfo
+	if err := json.Unmarshal([]byte(manifestContent), &data); err != nil {
+		fmt.Printf("✗ Failed to parse skill.json in the package: %v\n", err)
+		return false
+	}
+
+	fmt.Printf("\n📋 Preflight report for %s@%s\n", data.Name, data.Version)
+
+	// --- Manifest ---
+	fmt.Println("\nManifest:")
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, []byte(manifestContent), "  ", "  "); err != nil {
+		pretty.WriteString(manifestContent)
+	}
+	fmt.Printf("  %s\n", strings.TrimSpace(pretty.String()))
+
+	// --- Archive ---
+	entries, err := listArchive(packagePath)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return false
+	}
+	info, err := os.Stat(packagePath)
+	if err != nil {
+		fmt.Printf("✗ Failed to read package: %v\n", err)
+		return false
+	}
+	fmt.Printf("\nArchive: %s (%s, %d files)\n", filepath.Base(packagePath), formatSize(info.Size()), len(entries))
+	for _, entry := range entries {
+		fmt.Printf("  %-60s %10s\n", entry.Name, formatSize(int64(entry.UncompressedSize64)))
+	}
+
+	// --- Registry ---
+	fmt.Println("\nRegistry:")
+	dryRun, err := client.PublishDryRun(packagePath, utils.PublishOptions{Org: publishOrg})
+	checkedBy := "registry"
+	if errors.Is(err, utils.ErrDryRunUnsupported) {
+		checkedBy = "local emulation; the registry has no dry-run endpoint"
+		dryRun, err = emulateDryRun(client, data, manifestContent, entries, info.Size())
+	}
+	if err != nil {
+		fmt.Printf("  ✗ Could not check the package against the registry: %v\n", err)
+		return false
+	}
+
+	switch dryRun.NameStatus {
+	case utils.NameOwned:
+		fmt.Printf("  ✅ Name: '%s' is owned by %s\n", data.Name, ownerLabel())
+	case utils.NameAvailable:
+		fmt.Printf("  ✅ Name: '%s' is available\n", data.Name)
+	default:
+		fmt.Printf("  ❌ Name: '%s' is already taken by another publisher\n", data.Name)
+		ok = false
+	}
+
+	if dryRun.LatestVersion == "" {
+		fmt.Printf("  ✅ Version: %s will be the first published version\n", data.Version)
+	} else if newer, err := isNewerVersion(data.Version, dryRun.LatestVersion); err != nil {
+		fmt.Printf("  ❌ Version: %v\n", err)
+		ok = false
+	} else if newer {
+		fmt.Printf("  ✅ Version: %s is greater than the latest published version %s\n", data.Version, dryRun.LatestVersion)
+	} else {
+		fmt.Printf("  ❌ Version: %s is not greater than the latest published version %s\n", data.Version, dryRun.LatestVersion)
+		ok = false
+	}
+
+	if len(dryRun.Violations) == 0 {
+		fmt.Printf("  ✅ Policy: no violations (checked by %s)\n", checkedBy)
+	} else {
+		fmt.Printf("  Policy violations (checked by %s):\n", checkedBy)
+		for _, v := range dryRun.Violations {
+			icon := "❌"
+			if v.Severity == "warning" {
+				icon = "⚠️ "
+			} else {
+				ok = false
+			}
+			fmt.Printf("  %s [%s] %s\n", icon, v.Rule, v.Message)
+		}
+	}
+
+	if ok {
+		fmt.Println("\n✨ Dry run complete: the package would be accepted. Nothing was uploaded.")
+	} else {
+		fmt.Println("\n✗ Dry run complete: the package would be rejected. Nothing was uploaded.")
+	}
+	return ok
+}
+
+// emulateDryRun approximates the registry's dry-run checks using the regular
+// API and local policy rules.
+func emulateDryRun(client *utils.SkilzyClient, data manifestInfo, manifestContent string, entries []*zip.File, size int64) (*utils.PublishDryRunResponse, error) {
+	result := &utils.PublishDryRunResponse{Skill: data.Name, Version: data.Version}
+
+	// Name ownership: look in the caller's (or organization's) skills first
+	owned, err := findOwnedSkill(client, publishOrg, data.Name)
+	if err != nil {
+		return nil, err
+	}
+	if owned != nil {
+		result.NameStatus = utils.NameOwned
+		if owned.LatestVersion != nil {
+			result.LatestVersion = owned.LatestVersion.Version
+		}
+	} else {
+		result.NameStatus = utils.NameAvailable
+		search, err := client.SearchSkills(data.Name, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, skill := range search.Data {
+			if skill.Name == data.Name {
+				result.NameStatus = utils.NameTaken
+				result.LatestVersion = skill.LatestVersion
+				break
+			}
+		}
+	}
+
+	if exists, err := client.SkillVersionExists(publishOrg, data.Name, data.Version); err != nil {
+		return nil, err
+	} else if exists {
+		result.Violations = append(result.Violations, utils.PolicyViolation{Rule: "version-exists", Severity: "error",
+			Message: fmt.Sprintf("version %s has already been published", data.Version)})
+	}
+
+	result.Violations = append(result.Violations, localPolicyViolations(data, manifestContent, entries, size)...)
+	return result, nil
+}
+
+// localPolicyViolations checks the package against the registry's documented upload rules.
+func localPolicyViolations(data manifestInfo, manifestContent string, entries []*zip.File, size int64) []utils.PolicyViolation {
+	var violations []utils.PolicyViolation
+
+	schemaErrors, err := validateManifestSchema([]byte(manifestContent))
+	if err != nil {
+		schemaErrors = []string{err.Error()}
+	}
+	for _, e := range schemaErrors {
+		violations = append(violations, utils.PolicyViolation{Rule: "manifest-schema", Severity: "error", Message: e})
+	}
+
+	if size > maxPackageSize {
+		violations = append(violations, utils.PolicyViolation{Rule: "package-size", Severity: "error",
+			Message: fmt.Sprintf("package is %s, above the %s limit", formatSize(size), formatSize(maxPackageSize))})
+	}
+
+	root := data.Name + "/"
+	hasManifest := false
+	for _, entry := range entries {
+		name := entry.Name
+		if strings.HasPrefix(name, "/") || hasParentSegment(name) {
+			violations = append(violations, utils.PolicyViolation{Rule: "unsafe-path", Severity: "error",
+				Message: fmt.Sprintf("'%s' escapes the package root", name)})
+		} else if !strings.HasPrefix(name, root) {
+			violations = append(violations, utils.PolicyViolation{Rule: "package-root", Severity: "error",
+				Message: fmt.Sprintf("'%s' is not inside the '%s' root folder", name, root)})
+		}
+		if name == root+"skill.json" {
+			hasManifest = true
+		}
+	}
+	if !hasManifest {
+		violations = append(violations, utils.PolicyViolation{Rule: "package-root", Severity: "error",
+			Message: fmt.Sprintf("skill.json must be at '%sskill.json'", root)})
+	}
+	return violations
+}
+
+// listArchive returns the file entries in a package, excluding directories.
+func listArchive(packagePath string) ([]*zip.File, error) {
+	reader, err := zip.OpenReader(packagePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open package: %w", err)
+	}
+	defer reader.Close()
+
+	var files []*zip.File
+	for _, file := range reader.File {
+		if !file.FileInfo().IsDir() {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// ownerLabel describes who a publish would be attributed to.
+func ownerLabel() string {
+	if publishOrg != "" {
+		return "organization " + publishOrg
+	}
+	return "you"
+}
+
+// formatSize renders a byte count in human-readable units.
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// hasParentSegment reports whether an archive entry name has a ".." path
+// segment. Names such as "notes..v2.md" are fine.
+func hasParentSegment(name string) bool {
+	for _, segment := range strings.Split(name, "/") {
+		if segment == ".." {
+			return true
+		}
+	}
+	return false
+}
@@ -2,44 +2,100 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/skilzy/skilzy-cli/semver"
 	"github.com/skilzy/skilzy-cli/utils"
 	"github.com/spf13/cobra"
 )
 
+var (
+	publishOrg          string
+	publishDryRun       bool
+	publishWait         bool
+	publishTimeout      time.Duration
+	publishPollInterval time.Duration
+	publishAllowSecrets bool
+)
+
 var publishCmd = &cobra.Command{
-	Use:   "publish <path/to/package.skill>",
+	Use:   "publish [path/to/skill-or-package]",
 	Short: "Publish a skill to the Skilzy Registry",
 	Long: `Publish a new or updated skill to the registry. Requires authentication via 'skilzy login'.
 
-The package file should be a .skill or .zip file created with the 'skilzy package' command.`,
-	Args: cobra.ExactArgs(1),
+The path may be a skill directory or a .skill/.zip package created with the
+'skilzy package' command. With no argument, the skill in the current directory
+is validated, packaged into a temporary file and uploaded in one step.
+
+Before uploading, the package's skill.json is checked against the schema and
+the registry is asked whether the version has already been published.
+Use --org to publish the skill under an organization namespace you belong to.
+
+Use --wait to block until a version pending review is approved or rejected;
+the command exits non-zero on rejection or timeout, for use in release pipelines.
+
+Use --dry-run to print a preflight report (resolved manifest, archive contents,
+name ownership, version ordering and registry policy checks) without uploading.
+
+With --workspace, or when run in a skilzy-workspace.json root, every member
+whose version is not yet published is validated and published, dependencies
+first; a skill is skipped if one it depends on fails. --changed <ref> limits
+this to skills with changes since the git ref.`,
+	Args: cobra.MaximumNArgs(1),
 	Run:  runPublish,
 }
 
 func init() {
 	rootCmd.AddCommand(publishCmd)
+	publishCmd.Flags().StringVar(&publishOrg, "org", "", "Publish under an organization namespace instead of your account")
+	publishCmd.Flags().BoolVar(&publishDryRun, "dry-run", false, "Report what would be published without uploading")
+	publishCmd.Flags().BoolVar(&publishWait, "wait", false, "Wait until the published version has been reviewed")
+	publishCmd.Flags().DurationVar(&publishTimeout, "timeout", 30*time.Minute, "Maximum time to wait for review with --wait")
+	publishCmd.Flags().DurationVar(&publishPollInterval, "poll-interval", 15*time.Second, "How often to check the review status with --wait")
+	publishCmd.Flags().BoolVar(&publishAllowSecrets, "allow-secrets", false, "Publish even if the secret scan finds something")
+	publishCmd.MarkFlagsMutuallyExclusive("dry-run", "wait")
+	addWorkspaceFlags(publishCmd)
 }
 
 func runPublish(cmd *cobra.Command, args []string) {
-	packagePath := args[0]
-
-	fmt.Printf("📦 Publishing skill from: %s\n", packagePath)
+	if publishWait && publishPollInterval <= 0 {
+		fmt.Println("✗ --poll-interval must be greater than zero.")
+		os.Exit(1)
+	}
+	if publishWait && publishTimeout <= 0 {
+		fmt.Println("✗ --timeout must be greater than zero.")
+		os.Exit(1)
+	}
+	if (workspaceAll || workspaceChanged != "") && len(args) > 0 {
+		fmt.Println("✗ A path cannot be combined with --workspace or --changed.")
+		os.Exit(1)
+	}
+	target := "."
+	if len(args) > 0 {
+		target = args[0]
+	}
 
-	// Validate package file exists
-	absPath, err := filepath.Abs(packagePath)
+	// Validate the path exists
+	absPath, err := filepath.Abs(target)
 	if err != nil {
 		fmt.Printf("✗ Invalid path: %v\n", err)
 		os.Exit(1)
 	}
 
-	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+	info, err := os.Stat(absPath)
+	if os.IsNotExist(err) {
 		fmt.Printf("✗ Skill package not found at '%s'\n", absPath)
 		os.Exit(1)
 	}
+	if err != nil {
+		fmt.Printf("✗ Failed to read '%s': %v\n", absPath, err)
+		os.Exit(1)
+	}
 
 	// Load API key
 	apiKey, err := utils.LoadAPIKey()
@@ -57,21 +113,171 @@ func runPublish(cmd *cobra.Command, args []string) {
 	// Create API client
 	client := utils.NewSkilzyClient(apiKey)
 
+	if usingWorkspace(args) {
+		if !runWorkspacePublish(client) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if !info.IsDir() {
+		fmt.Printf("📦 Publishing skill from: %s\n", absPath)
+		if !publishPackage(client, absPath) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Printf("📦 Publishing skill from directory: %s\n", absPath)
+	tempDir, err := os.MkdirTemp("", "skilzy-publish-*")
+	if err != nil {
+		fmt.Printf("✗ Failed to create temporary directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	ok := false
+	if packagePath, err := packageForPublish(absPath, tempDir); err != nil {
+		fmt.Printf("\n✗ %v\n", err)
+	} else {
+		ok = publishPackage(client, packagePath)
+	}
+	os.RemoveAll(tempDir)
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// packageForPublish validates the skill in skillDir and packages it into tempDir,
+// returning the path of the created archive.
+func packageForPublish(skillDir, tempDir string) (string, error) {
+	fmt.Println("\n🔍 Validating skill...")
+	validationErrors := doValidation(skillDir)
+	if len(validationErrors) > 0 {
+		fmt.Println()
+		for _, e := range validationErrors {
+			fmt.Println(e)
+		}
+		return "", fmt.Errorf("validation failed; cannot publish an invalid skill")
+	}
+
+	data, err := readManifestInfo(skillDir)
+	if err != nil {
+		return "", err
+	}
+
+	archivePath := filepath.Join(tempDir, data.archiveName())
+	if err := createPackage(skillDir, data, archivePath, ""); err != nil {
+		return "", err
+	}
+	fmt.Printf("✅ Packaged %s\n", data.archiveName())
+	return archivePath, nil
+}
+
+// publishPackage runs the pre-publish checks on a package and uploads it,
+// printing progress and results. It reports whether the publish succeeded.
+func publishPackage(client *utils.SkilzyClient, packagePath string) bool {
+	secretsOK := checkPackageSecrets(packagePath, publishAllowSecrets)
+	if publishDryRun {
+		return runPreflight(client, packagePath) && secretsOK
+	}
+	if !secretsOK {
+		fmt.Println("\n✗ Publishing aborted. Nothing was uploaded.")
+		return false
+	}
+
+	fmt.Println("\n🔍 Running pre-publish checks...")
+	if err := prePublishChecks(client, packagePath); err != nil {
+		fmt.Printf("\n✗ Pre-publish checks failed: %v\n", err)
+		return false
+	}
+	fmt.Println("✅ Pre-publish checks passed.")
+
+	if publishOrg != "" {
+		fmt.Printf("\n🏢 Publishing under organization: %s\n", publishOrg)
+	}
+
 	// Publish the skill
 	fmt.Println("\n📤 Uploading skill package...")
-	response, err := client.PublishSkill(absPath)
+	response, err := client.PublishSkill(packagePath, utils.PublishOptions{Org: publishOrg})
 	if err != nil {
 		fmt.Printf("\n✗ Failed to publish skill: %v\n", err)
-		os.Exit(1)
+		return false
 	}
 
 	// Show success message
 	fmt.Println("\n✓ Publish request successful!")
 	fmt.Printf("  - Skill: %s\n", response.Skill)
+	if response.Owner != "" {
+		fmt.Printf("  - Owner: %s\n", response.Owner)
+	}
 	fmt.Printf("  - Version: %s\n", response.Version)
 	fmt.Printf("  - Status: %s\n", response.Status)
 
-	if response.Status == "pending_review" {
+	if response.Status == utils.StatusPendingReview {
+		if publishWait {
+			return waitForReview(client, publishOrg, response.Skill, response.Version, publishTimeout, publishPollInterval)
+		}
 		fmt.Println("\nℹ️  Your skill is now pending review. You'll be notified when it's approved.")
+		fmt.Printf("   Check its progress with 'skilzy status %s@%s'.\n", response.Skill, response.Version)
+	}
+	return true
+}
+
+// prePublishChecks refuses packages whose manifest fails the schema or whose
+// version has already been published or is not greater than the latest
+// published version.
+func prePublishChecks(client *utils.SkilzyClient, packagePath string) error {
+	manifestContent, err := utils.ExtractManifestFromZip(packagePath)
+	if err != nil {
+		return err
+	}
+
+	schemaErrors, err := validateManifestSchema([]byte(manifestContent))
+	if err != nil {
+		return fmt.Errorf("skill.json in the package could not be validated: %w", err)
+	}
+	if len(schemaErrors) > 0 {
+		return fmt.Errorf("skill.json in the package fails schema validation:\n  - %s", strings.Join(schemaErrors, "\n  - "))
+	}
+
+	var data manifestInfo
+	if err := json.Unmarshal([]byte(manifestContent), &data); err != nil {
+		return fmt.Errorf("failed to parse skill.json in the package: %w", err)
+	}
+
+	exists, err := client.SkillVersionExists(publishOrg, data.Name, data.Version)
+	if err != nil {
+		return fmt.Errorf("could not check whether %s@%s is already published: %w", data.Name, data.Version, err)
+	}
+	if exists {
+		return fmt.Errorf("version %s of '%s' has already been published. Bump the version in skill.json and try again", data.Version, data.Name)
+	}
+
+	owned, err := findOwnedSkill(client, publishOrg, data.Name)
+	if err != nil {
+		return fmt.Errorf("could not look up the latest published version of '%s': %w", data.Name, err)
+	}
+	if owned != nil && owned.LatestVersion != nil {
+		newer, err := isNewerVersion(data.Version, owned.LatestVersion.Version)
+		if err != nil {
+			return err
+		}
+		if !newer {
+			return fmt.Errorf("version %s of '%s' is not greater than the latest published version %s. Bump the version in skill.json and try again", data.Version, data.Name, owned.LatestVersion.Version)
+		}
+	}
+	return nil
+}
+
+// isNewerVersion reports whether version has higher SemVer precedence than latest.
+func isNewerVersion(version, latest string) (bool, error) {
+	v, err := semver.Parse(version)
+	if err != nil {
+		return false, err
+	}
+	l, err := semver.Parse(latest)
+	if err != nil {
+		return false, fmt.Errorf("the latest published version '%s' is not valid SemVer: %w", latest, err)
 	}
+	return v.GreaterThan(l), nil
 }
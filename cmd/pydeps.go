@@ -0,0 +1,424 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// pyRequirement is a parsed PEP 508 requirement.
+type pyRequirement struct {
+	Name      string // as written
+	Extras    []string
+	Specifier string // e.g. ">=2.31,<3"; empty if unversioned
+	URL       string // for "name @ url" requirements
+	Marker    string // environment marker after ';'
+}
+
+var (
+	pyNamePattern      = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?`)
+	pyExtrasPattern    = regexp.MustCompile(`^\[\s*([A-Za-z0-9][A-Za-z0-9._-]*(?:\s*,\s*[A-Za-z0-9][A-Za-z0-9._-]*)*)?\s*\]`)
+	pyVersionClause    = regexp.MustCompile(`^(~=|===|==|!=|<=|>=|<|>)\s*([A-Za-z0-9_.*+!-]+)$`)
+	pyPEP440Pattern    = regexp.MustCompile(`(?i)^v?(?:\d+!)?\d+(?:\.\d+)*(?:\.\*)?(?:[-_.]?(?:a|b|c|rc|alpha|beta|pre|preview)[-_.]?\d*)?(?:(?:-\d+)|(?:[-_.]?(?:post|rev|r)[-_.]?\d*))?(?:[-_.]?dev[-_.]?\d*)?(?:\+[a-z0-9]+(?:[-_.][a-z0-9]+)*)?$`)
+	pyNormalizePattern = regexp.MustCompile(`[-_.]+`)
+)
+
+// parsePyRequirement parses a requirement such as "requests[socks]>=2.31,<3; python_version >= '3.9'".
+func parsePyRequirement(s string) (pyRequirement, error) {
+	var req pyRequirement
+	rest := strings.TrimSpace(s)
+
+	if spec, marker, ok := strings.Cut(rest, ";"); ok {
+		req.Marker = strings.TrimSpace(marker)
+		if req.Marker == "" {
+			return req, fmt.Errorf("empty environment marker after ';'")
+		}
+		rest = strings.TrimSpace(spec)
+	}
+
+	name := pyNamePattern.FindString(rest)
+	if name == "" {
+		return req, fmt.Errorf("missing or invalid project name")
+	}
+	req.Name = name
+	rest = strings.TrimSpace(rest[len(name):])
+
+	if strings.HasPrefix(rest, "[") {
+		m := pyExtrasPattern.FindStringSubmatch(rest)
+		if m == nil {
+			return req, fmt.Errorf("malformed extras")
+		}
+		for _, extra := range strings.Split(m[1], ",") {
+			if extra = strings.TrimSpace(extra); extra != "" {
+				req.Extras = append(req.Extras, extra)
+			}
+		}
+		rest = strings.TrimSpace(rest[len(m[0]):])
+	}
+
+	if url, ok := strings.CutPrefix(rest, "@"); ok {
+		req.URL = strings.TrimSpace(url)
+		if !strings.Contains(req.URL, "://") {
+			return req, fmt.Errorf("'%s' is not a URL", req.URL)
+		}
+		return req, nil
+	}
+
+	if strings.HasPrefix(rest, "(") && strings.HasSuffix(rest, ")") {
+		rest = strings.TrimSpace(rest[1 : len(rest)-1])
+	}
+	if rest == "" {
+		return req, nil
+	}
+	for _, clause := range strings.Split(rest, ",") {
+		clause = strings.TrimSpace(clause)
+		m := pyVersionClause.FindStringSubmatch(clause)
+		if m == nil {
+			return req, fmt.Errorf("'%s' is not a version specifier (e.g. '>=2.31' or '~=1.4')", clause)
+		}
+		if m[1] != "===" && !pyPEP440Pattern.MatchString(m[2]) {
+			return req, fmt.Errorf("'%s' is not a valid version", m[2])
+		}
+		if strings.HasSuffix(m[2], ".*") && m[1] != "==" && m[1] != "!=" {
+			return req, fmt.Errorf("wildcard versions are only allowed with '==' and '!='")
+		}
+	}
+	req.Specifier = strings.Join(strings.Fields(rest), "")
+	return req, nil
+}
+
+// normalizePyName normalizes a project name as in PEP 503, so "PyYAML" and "pyyaml" compare equal.
+func normalizePyName(name string) string {
+	return pyNormalizePattern.ReplaceAllString(strings.ToLower(name), "-")
+}
+
+// performPythonDependencyChecks parses the manifest's dependencies.python entries,
+// compares them with requirements.txt and pyproject.toml, and looks for imports
+// in scripts/ that no dependency provides. Problems that make the manifest wrong
+// are returned as errors; the rest as warnings.
+func performPythonDependencyChecks(skillDir string, manifestContent []byte) (errors, warnings []string) {
+	var data struct {
+		Dependencies struct {
+			Python []string `json:"python"`
+		} `json:"dependencies"`
+	}
+	json.Unmarshal(manifestContent, &data)
+
+	declared := map[string]pyRequirement{}
+	for _, entry := range data.Dependencies.Python {
+		req, err := parsePyRequirement(entry)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("dependencies.python: '%s' is not a valid PEP 508 requirement: %v.", entry, err))
+			continue
+		}
+		key := normalizePyName(req.Name)
+		if _, dup := declared[key]; dup {
+			errors = append(errors, fmt.Sprintf("dependencies.python: '%s' is declared more than once.", req.Name))
+		}
+		declared[key] = req
+		if req.Specifier == "" && req.URL == "" {
+			warnings = append(warnings, fmt.Sprintf("dependencies.python: '%s' is unpinned; add a version constraint such as '~=' or '=='.", entry))
+		}
+	}
+
+	// Cross-check the project's own dependency files
+	for _, source := range []struct {
+		file string
+		read func(string) ([]string, error)
+	}{
+		{"requirements.txt", readRequirementsTxt},
+		{"pyproject.toml", readPyprojectDependencies},
+	} {
+		entries, err := source.read(filepath.Join(skillDir, source.file))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Could not read %s: %v.", source.file, err))
+			continue
+		}
+		listed := map[string]bool{}
+		for _, entry := range entries {
+			req, err := parsePyRequirement(entry)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("%s: '%s' is not a valid PEP 508 requirement: %v.", source.file, entry, err))
+				continue
+			}
+			key := normalizePyName(req.Name)
+			listed[key] = true
+			manifestReq, ok := declared[key]
+			if !ok {
+				errors = append(errors, fmt.Sprintf("'%s' is listed in %s but missing from dependencies.python.", req.Name, source.file))
+			} else if req.Specifier != manifestReq.Specifier {
+				warnings = append(warnings, fmt.Sprintf("'%s' has version %s in %s but %s in dependencies.python.", req.Name, describePySpecifier(req.Specifier), source.file, describePySpecifier(manifestReq.Specifier)))
+			}
+		}
+		for key, req := range declared {
+			if !listed[key] {
+				warnings = append(warnings, fmt.Sprintf("'%s' is in dependencies.python but not in %s.", req.Name, source.file))
+			}
+		}
+	}
+
+	// Imports in bundled scripts
+	for _, imp := range undeclaredImports(skillDir, declared) {
+		warnings = append(warnings, fmt.Sprintf("%s imports '%s', which is not provided by any entry in dependencies.python.", imp.location, imp.module))
+	}
+
+	sort.Strings(warnings)
+	return errors, warnings
+}
+
+// readRequirementsTxt returns the requirements in a requirements.txt file,
+// skipping comments, options and editable or nested requirement files.
+func readRequirementsTxt(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		entries = append(entries, line)
+	}
+	return entries, scanner.Err()
+}
+
+var (
+	tomlTablePattern  = regexp.MustCompile(`^\s*\[([^\[\]]+)\]\s*$`)
+	tomlStringPattern = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"|'([^']*)'`)
+	tomlKeyPattern    = regexp.MustCompile(`^\s*([A-Za-z0-9_.-]+|"[^"]+")\s*=`)
+)
+
+// readPyprojectDependencies returns the dependencies declared in a pyproject.toml,
+// from [project] dependencies (PEP 621) or, as bare names, [tool.poetry.dependencies].
+func readPyprojectDependencies(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []string
+	table := ""
+	inArray := false
+	for _, line := range strings.Split(string(content), "\n") {
+		if i := strings.Index(line, "#"); i >= 0 && !strings.ContainsAny(line[:i], `"'`) {
+			line = line[:i]
+		}
+		if inArray {
+			for _, m := range tomlStringPattern.FindAllStringSubmatch(line, -1) {
+				entries = append(entries, m[1]+m[2])
+			}
+			if strings.Contains(line, "]") {
+				inArray = false
+			}
+			continue
+		}
+		if m := tomlTablePattern.FindStringSubmatch(line); m != nil {
+			table = strings.TrimSpace(m[1])
+			continue
+		}
+
+		switch table {
+		case "project":
+			key, value, ok := strings.Cut(line, "=")
+			if !ok || strings.TrimSpace(key) != "dependencies" {
+				continue
+			}
+			value, _, _ = strings.Cut(value, "]")
+			for _, m := range tomlStringPattern.FindAllStringSubmatch(value, -1) {
+				entries = append(entries, m[1]+m[2])
+			}
+			inArray = strings.Contains(line, "[") && !strings.Contains(line, "]")
+		case "tool.poetry.dependencies":
+			if m := tomlKeyPattern.FindStringSubmatch(line); m != nil {
+				if name := strings.Trim(m[1], `"`); name != "python" {
+					entries = append(entries, name)
+				}
+			}
+		}
+	}
+	return entries, nil
+}
+
+// scriptImport is a top-level module imported by a script.
+type scriptImport struct {
+	module   string
+	location string
+}
+
+var (
+	pyImportPattern     = regexp.MustCompile(`^\s*import\s+(.+)$`)
+	pyFromImportPattern = regexp.MustCompile(`^\s*from\s+([A-Za-z_][\w.]*)\s+import\b`)
+)
+
+// pyImportDistributions maps import names to the distributions that provide
+// them where the two differ.
+var pyImportDistributions = map[string][]string{
+	"yaml":        {"pyyaml"},
+	"cv2":         {"opencv-python", "opencv-python-headless", "opencv-contrib-python"},
+	"PIL":         {"pillow"},
+	"bs4":         {"beautifulsoup4"},
+	"sklearn":     {"scikit-learn"},
+	"skimage":     {"scikit-image"},
+	"dateutil":    {"python-dateutil"},
+	"dotenv":      {"python-dotenv"},
+	"jwt":         {"pyjwt"},
+	"docx":        {"python-docx"},
+	"pptx":        {"python-pptx"},
+	"fitz":        {"pymupdf"},
+	"Crypto":      {"pycryptodome"},
+	"OpenSSL":     {"pyopenssl"},
+	"magic":       {"python-magic"},
+	"attr":        {"attrs"},
+	"serial":      {"pyserial"},
+	"usb":         {"pyusb"},
+	"google":      {"protobuf"},
+	"win32api":    {"pywin32"},
+	"gi":          {"pygobject"},
+	"MySQLdb":     {"mysqlclient"},
+	"zmq":         {"pyzmq"},
+	"Levenshtein": {"python-levenshtein", "levenshtein"},
+}
+
+// undeclaredImports returns the third-party modules imported by scripts/ that no
+// declared dependency appears to provide. Standard library modules and modules
+// bundled with the skill are ignored.
+func undeclaredImports(skillDir string, declared map[string]pyRequirement) []scriptImport {
+	scriptsDir := filepath.Join(skillDir, "scripts")
+	local := map[string]bool{}
+	var scripts []string
+	filepath.Walk(scriptsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := info.Name()
+		if info.IsDir() {
+			if path != scriptsDir && isIgnoredAuditDir(name) {
+				return filepath.SkipDir
+			}
+			local[name] = true
+			return nil
+		}
+		if strings.HasSuffix(name, ".py") {
+			local[strings.TrimSuffix(name, ".py")] = true
+			scripts = append(scripts, path)
+		}
+		return nil
+	})
+
+	var found []scriptImport
+	reported := map[string]bool{}
+	for _, script := range scripts {
+		content, err := os.ReadFile(script)
+		if err != nil {
+			continue
+		}
+		relPath, _ := filepath.Rel(skillDir, script)
+		for i, line := range strings.Split(string(content), "\n") {
+			for _, module := range importedModules(line) {
+				if reported[module] || local[module] || strings.HasPrefix(module, "_") || pythonStdlibModules[module] || importDeclared(module, declared) {
+					continue
+				}
+				reported[module] = true
+				found = append(found, scriptImport{module, fmt.Sprintf("%s:%d", filepath.ToSlash(relPath), i+1)})
+			}
+		}
+	}
+	return found
+}
+
+// importedModules returns the top-level modules imported by a line of Python.
+func importedModules(line string) []string {
+	if m := pyFromImportPattern.FindStringSubmatch(line); m != nil {
+		module, _, _ := strings.Cut(m[1], ".")
+		return []string{module}
+	}
+	m := pyImportPattern.FindStringSubmatch(line)
+	if m == nil {
+		return nil
+	}
+	var modules []string
+	for _, part := range strings.Split(m[1], ",") {
+		fields := strings.Fields(part) // "numpy as np"
+		if len(fields) == 0 {
+			continue
+		}
+		module, _, _ := strings.Cut(fields[0], ".")
+		if pyNamePattern.MatchString(module) {
+			modules = append(modules, module)
+		}
+	}
+	return modules
+}
+
+// describePySpecifier quotes a version specifier for messages.
+func describePySpecifier(spec string) string {
+	if spec == "" {
+		return "no constraint"
+	}
+	return "'" + spec + "'"
+}
+
+// importDeclared reports whether a declared dependency plausibly provides module.
+func importDeclared(module string, declared map[string]pyRequirement) bool {
+	key := normalizePyName(module)
+	if _, ok := declared[key]; ok {
+		return true
+	}
+	for _, dist := range pyImportDistributions[module] {
+		if _, ok := declared[dist]; ok {
+			return true
+		}
+	}
+	// Namespace packages such as google-cloud-storage or azure-identity
+	for name := range declared {
+		if strings.HasPrefix(name, key+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// pythonStdlibModules lists the top-level modules of the Python standard library.
+var pythonStdlibModules = func() map[string]bool {
+	modules := map[string]bool{}
+	for _, name := range strings.Fields(`
+abc aifc antigravity argparse array ast asynchat asyncio asyncore atexit audioop base64 bdb
+binascii bisect builtins bz2 cProfile calendar cgi cgitb chunk cmath cmd code codecs codeop
+collections colorsys compileall concurrent configparser contextlib contextvars copy copyreg
+crypt csv ctypes curses dataclasses datetime dbm decimal difflib dis distutils doctest email
+encodings ensurepip enum errno faulthandler fcntl filecmp fileinput fnmatch fractions ftplib
+functools gc genericpath getopt getpass gettext glob graphlib grp gzip hashlib heapq hmac
+html http idlelib imaplib imghdr imp importlib inspect io ipaddress itertools json keyword
+lib2to3 linecache locale logging lzma mailbox mailcap marshal math mimetypes mmap
+modulefinder msilib msvcrt multiprocessing netrc nis nntplib nt ntpath nturl2path numbers
+opcode operator optparse os ossaudiodev pathlib pdb pickle pickletools pipes pkgutil
+platform plistlib poplib posix posixpath pprint profile pstats pty pwd py_compile pyclbr
+pydoc pydoc_data pyexpat queue quopri random re readline reprlib resource rlcompleter runpy
+sched secrets select selectors shelve shlex shutil signal site smtpd smtplib sndhdr socket
+socketserver spwd sqlite3 sre_compile sre_constants sre_parse ssl stat statistics string
+stringprep struct subprocess sunau symtable sys sysconfig syslog tabnanny tarfile telnetlib
+tempfile termios textwrap this threading time timeit tkinter token tokenize tomllib trace
+traceback tracemalloc tty turtle turtledemo types typing unicodedata unittest urllib uu uuid
+venv warnings wave weakref webbrowser winreg winsound wsgiref xdrlib xml xmlrpc zipapp
+zipfile zipimport zlib zoneinfo`) {
+		modules[name] = true
+	}
+	return modules
+}()
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/skilzy/skilzy-cli/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -15,6 +17,14 @@ var rootCmd = &cobra.Command{
 It helps you create, validate, package, and publish skills for AI agents.`,
 }
 
+func init() {
+	utils.PassphrasePrompt = func() (string, error) {
+		var passphrase string
+		err := survey.AskOne(&survey.Password{Message: "Credential store passphrase:"}, &passphrase)
+		return passphrase, err
+	}
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
@@ -23,3 +33,21 @@ func Execute() {
 		os.Exit(1)
 	}
 }
+
+// newAuthenticatedClient loads the API key and returns a client for it,
+// exiting with a login hint if no key is configured.
+func newAuthenticatedClient() *utils.SkilzyClient {
+	apiKey, err := utils.LoadAPIKey()
+	if err != nil {
+		fmt.Printf("✗ Failed to load API key: %v\n", err)
+		os.Exit(1)
+	}
+
+	if apiKey == "" {
+		fmt.Println("✗ You must be logged in.")
+		fmt.Println("  Please run 'skilzy login' first.")
+		os.Exit(1)
+	}
+
+	return utils.NewSkilzyClient(apiKey)
+}
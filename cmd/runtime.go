@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/skilzy/skilzy-cli/scaffold"
+	"github.com/skilzy/skilzy-cli/semver"
+)
+
+// runtimeDescriptions are shown next to the runtime types when prompting.
+var runtimeDescriptions = map[string]string{
+	scaffold.RuntimePython: "Python scripts",
+	scaffold.RuntimeNode:   "JavaScript or TypeScript run with Node.js",
+	scaffold.RuntimeDeno:   "JavaScript or TypeScript run with Deno",
+	scaffold.RuntimeShell:  "Bash scripts",
+	scaffold.RuntimeNone:   "Instructions only, no scripts",
+}
+
+// askRuntime prompts for the runtime type and its version constraint.
+func askRuntime(defaultRuntime scaffold.Runtime) (scaffold.Runtime, error) {
+	runtime := scaffold.Runtime{Type: defaultRuntime.Type}
+	prompt := &survey.Select{
+		Message:     "Runtime:",
+		Options:     scaffold.RuntimeTypes,
+		Default:     defaultRuntime.Type,
+		Description: func(value string, index int) string { return runtimeDescriptions[value] },
+	}
+	if err := survey.AskOne(prompt, &runtime.Type); err != nil {
+		return runtime, err
+	}
+	if runtime.Type == scaffold.RuntimeNone {
+		return runtime, nil
+	}
+
+	runtime.Version = scaffold.DefaultRuntimeVersion(runtime.Type)
+	if runtime.Type == defaultRuntime.Type && defaultRuntime.Version != "" {
+		runtime.Version = defaultRuntime.Version
+	}
+	versionPrompt := &survey.Input{
+		Message: "Runtime version constraint (optional):",
+		Default: runtime.Version,
+		Help:    "An npm-style SemVer range, e.g. '>=3.9' or '^20'.",
+	}
+	if err := survey.AskOne(versionPrompt, &runtime.Version, survey.WithValidator(validateRuntimeVersion)); err != nil {
+		return runtime, err
+	}
+	runtime.Version = strings.TrimSpace(runtime.Version)
+	return runtime, nil
+}
+
+func validateRuntimeVersion(val interface{}) error {
+	if version := strings.TrimSpace(val.(string)); version != "" {
+		if _, err := semver.ParseRange(version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateRuntimeType checks that runtimeType is one of the supported runtimes.
+func validateRuntimeType(runtimeType string) error {
+	for _, t := range scaffold.RuntimeTypes {
+		if runtimeType == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown runtime '%s' (expected one of: %s)", runtimeType, strings.Join(scaffold.RuntimeTypes, ", "))
+}
+
+// scriptRuntimes maps script file extensions to the runtimes that run them.
+var scriptRuntimes = map[string][]string{
+	".py":  {scaffold.RuntimePython},
+	".js":  {scaffold.RuntimeNode, scaffold.RuntimeDeno},
+	".mjs": {scaffold.RuntimeNode, scaffold.RuntimeDeno},
+	".cjs": {scaffold.RuntimeNode},
+	".ts":  {scaffold.RuntimeDeno, scaffold.RuntimeNode},
+	".mts": {scaffold.RuntimeDeno, scaffold.RuntimeNode},
+	".sh":  {scaffold.RuntimeShell},
+}
+
+// detectRuntime guesses the runtime of an existing skill from its files:
+// deno.json or package.json decide between Deno and Node.js, otherwise the most
+// common script type wins. Skills without scripts are instruction-only.
+func detectRuntime(dir string) string {
+	if fileExists(filepath.Join(dir, "deno.json")) || fileExists(filepath.Join(dir, "deno.jsonc")) {
+		return scaffold.RuntimeDeno
+	}
+	if fileExists(filepath.Join(dir, "package.json")) {
+		return scaffold.RuntimeNode
+	}
+
+	counts := map[string]int{}
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if isIgnoredAuditDir(info.Name()) && path != dir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if runtimes := scriptRuntimes[filepath.Ext(path)]; len(runtimes) > 0 {
+			counts[runtimes[0]]++
+		}
+		return nil
+	})
+
+	detected, most := scaffold.RuntimeNone, 0
+	for _, t := range scaffold.RuntimeTypes {
+		if counts[t] > most {
+			detected, most = t, counts[t]
+		}
+	}
+	return detected
+}
+
+// manifestRuntimeType returns the manifest's runtime.type.
+func manifestRuntimeType(manifestContent []byte) string {
+	var data struct {
+		Runtime scaffold.Runtime `json:"runtime"`
+	}
+	json.Unmarshal(manifestContent, &data)
+	return data.Runtime.Type
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// performRuntimeChecks ensures the runtime, its dependency lists and the
+// bundled scripts agree with each other.
+func performRuntimeChecks(skillDir string, manifestContent []byte) (errors, warnings []string) {
+	var data struct {
+		Runtime      scaffold.Runtime      `json:"runtime"`
+		Dependencies scaffold.Dependencies `json:"dependencies"`
+	}
+	json.Unmarshal(manifestContent, &data)
+	runtimeType := data.Runtime.Type
+
+	if runtimeType == scaffold.RuntimeNone && data.Runtime.Version != "" {
+		errors = append(errors, "'runtime.version' must not be set when 'runtime.type' is 'none'.")
+	}
+	if data.Runtime.Version == "" && scaffold.DefaultRuntimeVersion(runtimeType) != "" {
+		warnings = append(warnings, fmt.Sprintf("'runtime.version' is not set; consider a constraint such as '%s'.", scaffold.DefaultRuntimeVersion(runtimeType)))
+	}
+
+	packageLists := []struct {
+		field    string
+		entries  []string
+		runtimes []string
+	}{
+		{"dependencies.python", data.Dependencies.Python, []string{scaffold.RuntimePython}},
+		{"dependencies.npm", data.Dependencies.Npm, []string{scaffold.RuntimeNode, scaffold.RuntimeDeno}},
+	}
+	for _, list := range packageLists {
+		if len(list.entries) > 0 && !containsString(list.runtimes, runtimeType) {
+			errors = append(errors, fmt.Sprintf("'%s' is only used by the %s runtime, but 'runtime.type' is '%s'.", list.field, strings.Join(list.runtimes, " and "), runtimeType))
+		}
+	}
+
+	// Bundled scripts the declared runtime cannot run
+	mismatched := map[string]bool{}
+	filepath.Walk(skillDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if isIgnoredAuditDir(info.Name()) && path != skillDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		runtimes := scriptRuntimes[filepath.Ext(path)]
+		if len(runtimes) == 0 || containsString(runtimes, runtimeType) {
+			return nil
+		}
+		// Any runtime may call out to a shell script
+		if runtimes[0] == scaffold.RuntimeShell && runtimeType != scaffold.RuntimeNone {
+			return nil
+		}
+		rel, _ := filepath.Rel(skillDir, path)
+		rel = filepath.ToSlash(rel)
+		if runtimeType == scaffold.RuntimeNone {
+			warnings = append(warnings, fmt.Sprintf("%s is a script, but 'runtime.type' is 'none'; declare the runtime it needs.", rel))
+		} else if !mismatched[runtimes[0]] {
+			mismatched[runtimes[0]] = true
+			warnings = append(warnings, fmt.Sprintf("%s needs the %s runtime, but 'runtime.type' is '%s'.", rel, runtimes[0], runtimeType))
+		}
+		return nil
+	})
+
+	sort.Strings(warnings)
+	return errors, warnings
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
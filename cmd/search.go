@@ -4,8 +4,10 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
+	"github.com/skilzy/skilzy-cli/semver"
 	"github.com/skilzy/skilzy-cli/utils"
 	"github.com/spf13/cobra"
 )
@@ -13,6 +15,7 @@ import (
 var (
 	searchAuthor   string
 	searchKeywords string
+	searchSort     string
 )
 
 var searchCmd = &cobra.Command{
@@ -24,7 +27,8 @@ and checking if a skill name is already taken before creating one.
 Examples:
   skilzy search "pdf"
   skilzy search "automation" --author skilzy-admin
-  skilzy search "data" --keywords csv,excel`,
+  skilzy search "data" --keywords csv,excel
+  skilzy search "pdf" --sort version`,
 	Args: cobra.ExactArgs(1),
 	Run:  runSearch,
 }
@@ -33,10 +37,15 @@ func init() {
 	rootCmd.AddCommand(searchCmd)
 	searchCmd.Flags().StringVar(&searchAuthor, "author", "", "Filter by author's username")
 	searchCmd.Flags().StringVar(&searchKeywords, "keywords", "", "Comma-separated keywords to filter by")
+	searchCmd.Flags().StringVar(&searchSort, "sort", "relevance", "Sort results by relevance, name or version (newest first)")
 }
 
 func runSearch(cmd *cobra.Command, args []string) {
 	query := args[0]
+	if searchSort != "relevance" && searchSort != "name" && searchSort != "version" {
+		fmt.Printf("✗ Invalid --sort '%s' (expected relevance, name or version)\n", searchSort)
+		os.Exit(1)
+	}
 
 	fmt.Printf("🔍 Searching for '%s'...\n\n", query)
 
@@ -66,6 +75,7 @@ func runSearch(cmd *cobra.Command, args []string) {
 	}
 
 	fmt.Printf("Found %d skill(s):\n\n", results.Total)
+	sortSearchResults(results.Data, searchSort)
 
 	// Print table header
 	fmt.Printf("%-30s %-20s %-15s %s\n", "NAME", "AUTHOR", "VERSION", "DESCRIPTION")
@@ -86,5 +96,28 @@ func runSearch(cmd *cobra.Command, args []string) {
 			desc = desc[:35] + "..."
 		}
 		fmt.Printf("%-30s %-20s %-15s %s\n", name, author, skill.LatestVersion, desc)
+		if skill.Deprecated != "" {
+			fmt.Printf("  ⚠️  deprecated: %s\n", skill.Deprecated)
+		}
+	}
+}
+
+// sortSearchResults orders results by name or by latest version, newest first.
+// Versions that are not valid SemVer sort last. "relevance" keeps the registry's order.
+func sortSearchResults(results []utils.SearchResult, by string) {
+	switch by {
+	case "name":
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].Author+"/"+results[i].Name < results[j].Author+"/"+results[j].Name
+		})
+	case "version":
+		sort.SliceStable(results, func(i, j int) bool {
+			vi, errI := semver.Parse(results[i].LatestVersion)
+			vj, errJ := semver.Parse(results[j].LatestVersion)
+			if errI != nil || errJ != nil {
+				return errI == nil && errJ != nil
+			}
+			return vi.GreaterThan(vj)
+		})
 	}
 }
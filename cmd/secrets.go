@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/skilzy/skilzy-cli/secrets"
+	"github.com/spf13/cobra"
+)
+
+var secretsUpdateBaseline bool
+
+var secretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "Find credentials before they are packaged",
+}
+
+var secretsScanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "Scan the skill in the current directory for secrets",
+	Long: `Scans every file in the skill for API keys and tokens of common cloud and AI
+providers, private keys, dotenv files and high-entropy values assigned to names
+like 'api_key' or 'password'. The same scan runs on every package built by
+'skilzy package' and 'skilzy publish', which refuse to continue on findings
+unless --allow-secrets is given.
+
+To accept a false positive, either add a '` + secrets.AllowComment + `' comment to
+the line (or '` + secrets.AllowNextLineComment + `' to the line above it), or
+record the current findings in ` + secrets.BaselineFileName + ` with
+--update-baseline. Baseline entries store fingerprints, not the secrets.
+
+Examples:
+  skilzy secrets scan
+  skilzy secrets scan --update-baseline`,
+	Args: cobra.NoArgs,
+	Run:  runSecretsScan,
+}
+
+func init() {
+	rootCmd.AddCommand(secretsCmd)
+	secretsCmd.AddCommand(secretsScanCmd)
+	secretsScanCmd.Flags().BoolVar(&secretsUpdateBaseline, "update-baseline", false, "Accept all current findings by writing them to "+secrets.BaselineFileName)
+}
+
+func runSecretsScan(cmd *cobra.Command, args []string) {
+	skillDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("✗ Error getting current directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("🔐 Scanning for secrets...")
+	result, err := secrets.ScanDir(skillDir, "")
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		os.Exit(1)
+	}
+
+	baselinePath := filepath.Join(skillDir, secrets.BaselineFileName)
+	if secretsUpdateBaseline {
+		if err := secrets.NewBaseline(result.Findings).Save(baselinePath); err != nil {
+			fmt.Printf("✗ %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Recorded %d finding(s) in %s\n", len(result.Findings), secrets.BaselineFileName)
+		return
+	}
+
+	baseline, err := secrets.LoadBaseline(baselinePath)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		os.Exit(1)
+	}
+	if !reportSecrets(result, baseline) {
+		os.Exit(1)
+	}
+}
+
+// checkPackageSecrets scans a built package and reports whether it may be
+// distributed: it has no findings outside its baseline, or allow is set.
+func checkPackageSecrets(archivePath string, allow bool) bool {
+	fmt.Println("\n🔐 Scanning package for secrets...")
+	result, err := secrets.ScanArchive(archivePath)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return false
+	}
+	baseline, err := secrets.LoadBaselineFromArchive(archivePath)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return false
+	}
+	if reportSecrets(result, baseline) {
+		return true
+	}
+	if allow {
+		fmt.Println("⚠️  Continuing anyway because --allow-secrets was given.")
+		return true
+	}
+	return false
+}
+
+// reportSecrets prints the findings not accepted by the baseline and reports
+// whether there were none.
+func reportSecrets(result *secrets.Result, baseline *secrets.Baseline) bool {
+	findings, accepted := baseline.Filter(result.Findings)
+	acceptedNote := ""
+	if accepted > 0 {
+		acceptedNote = fmt.Sprintf(" (%d accepted by %s)", accepted, secrets.BaselineFileName)
+	}
+
+	if len(findings) == 0 {
+		fmt.Printf("✅ No secrets found in %d file(s)%s.\n", result.Files, acceptedNote)
+		return true
+	}
+
+	for _, f := range findings {
+		fmt.Printf("❌ %s\n", f)
+	}
+	fmt.Printf("\n✗ Found %d potential secret(s) in %d file(s)%s.\n", len(findings), result.Files, acceptedNote)
+	fmt.Println("  Remove them and rotate any real credentials. For false positives, add a")
+	fmt.Printf("  '%s' comment to the line, or run 'skilzy secrets scan --update-baseline'.\n", secrets.AllowComment)
+	return false
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/skilzy/skilzy-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var statusOrg string
+
+var statusCmd = &cobra.Command{
+	Use:   "status <skill>[@version]",
+	Short: "Show the review status of a published skill version",
+	Long: `Shows the review state and reviewer notes for a version of one of your skills.
+Without a version, the latest published version is shown.
+
+Examples:
+  skilzy status my-skill
+  skilzy status my-skill@1.2.0
+  skilzy status shared-skill --org acme`,
+	Args: cobra.ExactArgs(1),
+	Run:  runStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().StringVar(&statusOrg, "org", "", "Look up a skill published under an organization")
+}
+
+func runStatus(cmd *cobra.Command, args []string) {
+	name, version := splitSkillRef(args[0])
+	client := newAuthenticatedClient()
+
+	if version != "" {
+		skillVersion, err := client.GetSkillVersion(statusOrg, name, version)
+		if err != nil {
+			fmt.Printf("✗ Failed to retrieve status for %s@%s: %v\n", name, version, err)
+			os.Exit(1)
+		}
+		printVersionStatus(name, skillVersion)
+		return
+	}
+
+	skill, err := findOwnedSkill(client, statusOrg, name)
+	if err != nil {
+		fmt.Printf("✗ Failed to retrieve skills: %v\n", err)
+		os.Exit(1)
+	}
+	if skill == nil {
+		fmt.Printf("✗ Skill '%s' not found among your published skills.\n", name)
+		os.Exit(1)
+	}
+	if skill.LatestVersion == nil {
+		fmt.Printf("'%s' has no published versions.\n", name)
+		return
+	}
+	printVersionStatus(name, &utils.SkillVersion{
+		Version:     skill.LatestVersion.Version,
+		Status:      skill.LatestVersion.Status,
+		ReviewNotes: skill.LatestVersion.ReviewNotes,
+	})
+}
+
+// splitSkillRef splits a "<skill>[@version]" argument into its name and version parts.
+func splitSkillRef(ref string) (string, string) {
+	name, version, _ := strings.Cut(ref, "@")
+	return name, version
+}
+
+// findOwnedSkill looks up a skill among the caller's skills, or the given
+// organization's, returning nil if it is not found.
+func findOwnedSkill(client *utils.SkilzyClient, org, name string) (*utils.MySkill, error) {
+	var skills []utils.MySkill
+	var err error
+	if org != "" {
+		skills, err = client.GetOrgSkills(org)
+	} else {
+		skills, err = client.GetMySkills()
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range skills {
+		if skills[i].Name == name {
+			return &skills[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// printVersionStatus prints the review state and notes of a skill version.
+func printVersionStatus(name string, skillVersion *utils.SkillVersion) {
+	fmt.Printf("%s@%s\n", name, skillVersion.Version)
+	fmt.Printf("  - Status: %s %s\n", statusIcon(skillVersion.Status), skillVersion.Status)
+	if skillVersion.Yanked {
+		fmt.Println("  - Yanked: yes (hidden from search and 'latest')")
+	}
+	if skillVersion.Deprecated != "" {
+		fmt.Printf("  - Deprecated: %s\n", skillVersion.Deprecated)
+	}
+	if skillVersion.CreatedAt != nil {
+		fmt.Printf("  - Submitted: %s\n", skillVersion.CreatedAt.Local().Format(time.RFC1123))
+	}
+	if skillVersion.ReviewedAt != nil {
+		fmt.Printf("  - Reviewed: %s\n", skillVersion.ReviewedAt.Local().Format(time.RFC1123))
+	}
+	if skillVersion.ReviewNotes != "" {
+		fmt.Println("  - Review notes:")
+		for _, line := range strings.Split(strings.TrimSpace(skillVersion.ReviewNotes), "\n") {
+			fmt.Printf("      %s\n", line)
+		}
+	}
+}
+
+// statusIcon returns an icon for a review status.
+func statusIcon(status string) string {
+	switch status {
+	case utils.StatusApproved, utils.StatusPublished:
+		return "✅"
+	case utils.StatusRejected:
+		return "❌"
+	case utils.StatusPendingReview:
+		return "⏳"
+	default:
+		return "•"
+	}
+}
+
+// waitForReview polls the registry until a pending version is approved or
+// rejected, or the timeout elapses. It reports whether the version was approved.
+func waitForReview(client *utils.SkilzyClient, owner, name, version string, timeout, interval time.Duration) bool {
+	fmt.Printf("\n⏳ Waiting up to %s for review of %s@%s...\n", timeout, name, version)
+	deadline := time.Now().Add(timeout)
+
+	for {
+		skillVersion, err := client.GetSkillVersion(owner, name, version)
+		if err != nil && !retryableStatusError(err) {
+			fmt.Printf("\n✗ Failed to check status: %v\n", err)
+			return false
+		}
+		if err != nil {
+			fmt.Printf("  ⚠️  Failed to check status: %v\n", err)
+		} else if skillVersion.Status != utils.StatusPendingReview {
+			fmt.Println()
+			printVersionStatus(name, skillVersion)
+			if skillVersion.Status == utils.StatusRejected {
+				fmt.Println("\n✗ The version was rejected.")
+				return false
+			}
+			return true
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			fmt.Printf("\n✗ Timed out after %s waiting for review. Check later with 'skilzy status %s@%s'.\n", timeout, name, version)
+			return false
+		}
+		time.Sleep(interval)
+	}
+}
+
+// retryableStatusError reports whether a failed status check may succeed when
+// retried: network errors and server errors may, but a rejected key, missing
+// access or an unknown version will not.
+func retryableStatusError(err error) bool {
+	if strings.Contains(err.Error(), "authentication failed") {
+		return false
+	}
+	var apiErr *utils.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+			return false
+		}
+	}
+	return true
+}
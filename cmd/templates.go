@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/skilzy/skilzy-cli/scaffold"
+	"github.com/skilzy/skilzy-cli/semver"
+)
+
+// askTemplate prompts for one of the built-in templates.
+func askTemplate() (string, error) {
+	templates, err := scaffold.BuiltinTemplates()
+	if err != nil {
+		return "", err
+	}
+	var names []string
+	descriptions := map[string]string{}
+	for _, t := range templates {
+		names = append(names, t.Name)
+		descriptions[t.Name] = t.Description
+	}
+	name := scaffold.DefaultTemplate
+	prompt := &survey.Select{
+		Message:     "Template:",
+		Options:     names,
+		Default:     name,
+		Description: func(value string, index int) string { return descriptions[value] },
+	}
+	err = survey.AskOne(prompt, &name)
+	return name, err
+}
+
+// printTemplates lists the built-in templates.
+func printTemplates() error {
+	templates, err := scaffold.BuiltinTemplates()
+	if err != nil {
+		return err
+	}
+	fmt.Println("Built-in templates:")
+	for _, t := range templates {
+		fmt.Printf("  %-16s %s\n", t.Name, t.Description)
+	}
+	fmt.Println("\nA template can also be a local directory or a git URL (append '#<ref>' for a branch or tag).")
+	return nil
+}
+
+// askTemplatePrompts asks the questions defined by a template. If interactive
+// is false, the prompts' defaults are used.
+func askTemplatePrompts(tmpl *scaffold.Template, interactive bool) (map[string]interface{}, error) {
+	answers := map[string]interface{}{}
+	for _, p := range tmpl.Prompts {
+		answer, err := p.DefaultAnswer()
+		if err != nil && !interactive {
+			return nil, err
+		}
+		if interactive {
+			answer, err = askTemplatePrompt(p, answer)
+			if err != nil {
+				return nil, err
+			}
+		}
+		answers[p.Name] = answer
+	}
+	return answers, nil
+}
+
+func askTemplatePrompt(p scaffold.Prompt, defaultAnswer interface{}) (interface{}, error) {
+	switch p.Type {
+	case scaffold.PromptConfirm:
+		answer, _ := defaultAnswer.(bool)
+		err := survey.AskOne(&survey.Confirm{Message: p.Message, Help: p.Help, Default: answer}, &answer)
+		return answer, err
+	case scaffold.PromptSelect:
+		answer, _ := defaultAnswer.(string)
+		err := survey.AskOne(&survey.Select{Message: p.Message, Help: p.Help, Options: p.Options, Default: answer}, &answer)
+		return answer, err
+	case scaffold.PromptList:
+		items, _ := defaultAnswer.([]string)
+		answer := strings.Join(items, ", ")
+		err := survey.AskOne(&survey.Input{Message: p.Message, Help: p.Help, Default: answer}, &answer, survey.WithValidator(func(val interface{}) error {
+			items := scaffold.SplitList(val.(string))
+			if p.Required && len(items) == 0 {
+				return fmt.Errorf("at least one value is required")
+			}
+			for _, item := range items {
+				if err := validatePromptField(p.Field, item); err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+		return scaffold.SplitList(answer), err
+	}
+	answer, _ := defaultAnswer.(string)
+	err := survey.AskOne(&survey.Input{Message: p.Message, Help: p.Help, Default: answer}, &answer, survey.WithValidator(func(val interface{}) error {
+		value := strings.TrimSpace(val.(string))
+		if p.Required && value == "" {
+			return fmt.Errorf("a value is required")
+		}
+		return validatePromptField(p.Field, value)
+	}))
+	return strings.TrimSpace(answer), err
+}
+
+// validatePromptField checks an answer that is stored in a manifest field.
+func validatePromptField(field, value string) error {
+	if value == "" {
+		return nil
+	}
+	switch field {
+	case "runtime.type":
+		return validateRuntimeType(value)
+	case "runtime.version":
+		_, err := semver.ParseRange(value)
+		return err
+	case "dependencies.python":
+		_, err := parsePyRequirement(value)
+		return err
+	case "dependencies.npm":
+		_, err := parseNpmRequirement(value)
+		return err
+	}
+	return nil
+}
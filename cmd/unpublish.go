@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+// unpublishGracePeriod is how long after publishing a version may still be removed.
+const unpublishGracePeriod = 72 * time.Hour
+
+var (
+	unpublishOrg string
+	unpublishYes bool
+)
+
+var unpublishCmd = &cobra.Command{
+	Use:   "unpublish <skill>@<version>",
+	Short: "Permanently remove a recently published version",
+	Long: `Permanently removes a version from the registry. This is only allowed within
+72 hours of publishing; after that, use 'skilzy yank' or 'skilzy deprecate'
+so that users who depend on the version are not broken.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runUnpublish,
+}
+
+func init() {
+	rootCmd.AddCommand(unpublishCmd)
+	unpublishCmd.Flags().StringVar(&unpublishOrg, "org", "", "The organization the skill is published under")
+	unpublishCmd.Flags().BoolVarP(&unpublishYes, "yes", "y", false, "Skip the confirmation prompt")
+}
+
+func runUnpublish(cmd *cobra.Command, args []string) {
+	name, version := splitSkillRef(args[0])
+	if version == "" {
+		fmt.Println("✗ A version is required, e.g. 'skilzy unpublish my-skill@1.2.0'.")
+		os.Exit(1)
+	}
+
+	client := newAuthenticatedClient()
+	skillVersion, err := client.GetSkillVersion(unpublishOrg, name, version)
+	if err != nil {
+		fmt.Printf("✗ Failed to find %s@%s: %v\n", name, version, err)
+		os.Exit(1)
+	}
+	if skillVersion.CreatedAt != nil && time.Since(*skillVersion.CreatedAt) > unpublishGracePeriod {
+		fmt.Printf("✗ %s@%s was published on %s, outside the %d-hour unpublish window.\n",
+			name, version, skillVersion.CreatedAt.Local().Format("2006-01-02 15:04"), int(unpublishGracePeriod.Hours()))
+		fmt.Printf("  Use 'skilzy yank %s@%s' to hide it instead.\n", name, version)
+		os.Exit(1)
+	}
+
+	if !unpublishYes {
+		confirmed := false
+		prompt := &survey.Confirm{Message: fmt.Sprintf("Permanently remove %s@%s? This cannot be undone.", name, version)}
+		if err := survey.AskOne(prompt, &confirmed); err != nil || !confirmed {
+			fmt.Println("✗ Aborted.")
+			os.Exit(1)
+		}
+	}
+
+	if err := client.UnpublishVersion(unpublishOrg, name, version); err != nil {
+		fmt.Printf("✗ Failed to unpublish %s@%s: %v\n", name, version, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✓ Unpublished %s@%s\n", name, version)
+}
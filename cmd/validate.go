@@ -4,39 +4,102 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"time"
 
+	"github.com/skilzy/skilzy-cli/scaffold"
 	"github.com/skilzy/skilzy-cli/schema"
+	"github.com/skilzy/skilzy-cli/semver"
 	"github.com/spf13/cobra"
 	"github.com/xeipuuv/gojsonschema"
 )
 
+var (
+	validateTokens      bool
+	validateTokenBudget int
+	validateJobs        int
+)
+
 // validateCmd represents the validate command
 var validateCmd = &cobra.Command{
-	Use:   "validate",
+	Use:   "validate [paths...]",
 	Short: "Validate a skill manifest against the official schema",
-	Long: `The validate command checks the skill in the current directory, ensuring
-the skill.json is valid and all file references are correct.`,
+	Long: `The validate command checks skills, ensuring the skill.json is valid and all
+file references are correct. With no arguments, the skill in the current
+directory is checked.
+
+Paths may be skill directories, .skill/.zip packages built by 'skilzy package',
+or glob patterns; quote patterns so the shell does not expand them. Matches of
+a pattern that are not skills are skipped. Several skills are validated
+concurrently (see --jobs), their reports are printed in the order given and
+followed by a summary. The command exits non-zero if any skill is invalid.
+
+With --workspace, or when run in a workspace root, the skills listed in
+skilzy-workspace.json are validated, and version ranges between them in
+'dependencies.skills' are checked. --changed <ref> limits this to skills with
+changes since the git ref.
+
+Examples:
+  skilzy validate
+  skilzy validate ../pdf-tools dist/pdf-tools-1.2.0.skill
+  skilzy validate 'skills/*' --jobs 8
+  skilzy validate --changed origin/main`,
 	Run:  runValidate,
-	Args: cobra.NoArgs,
+	Args: cobra.ArbitraryArgs,
 }
 
 func init() {
 	rootCmd.AddCommand(validateCmd)
+	validateCmd.Flags().BoolVar(&validateTokens, "tokens", false, "Print an estimate of the tokens SKILL.md adds to an agent's context")
+	validateCmd.Flags().IntVar(&validateTokenBudget, "token-budget", defaultTokenBudget, "Warn when SKILL.md is estimated to exceed this many tokens (0 to disable)")
+	validateCmd.Flags().IntVarP(&validateJobs, "jobs", "j", runtime.NumCPU(), "Number of skills to validate at once")
+	addWorkspaceFlags(validateCmd)
 }
 
 // runValidate is the function executed by the 'validate' command.
 func runValidate(cmd *cobra.Command, args []string) {
-	fmt.Println("🔍 Running skill validation...")
-	
-	skillDir, err := os.Getwd()
+	if usingWorkspace(args) {
+		if len(args) > 0 {
+			fmt.Println("❌ Paths cannot be combined with --workspace or --changed.")
+			os.Exit(1)
+		}
+		if !runWorkspaceValidate() {
+			os.Exit(1)
+		}
+		return
+	}
+
+	targets, err := resolveValidateTargets(args)
 	if err != nil {
-		fmt.Printf("❌ Error getting current directory: %v\n", err)
+		fmt.Printf("❌ %v\n", err)
 		os.Exit(1)
 	}
 
-	validationErrors := doValidation(skillDir)
+	if len(targets) > 1 {
+		start := time.Now()
+		results := validateTargets(targets, validateJobs)
+		printValidateSummary(results, time.Since(start))
+		for _, r := range results {
+			if len(r.errors) > 0 {
+				os.Exit(1)
+			}
+		}
+		return
+	}
+
+	fmt.Println("🔍 Running skill validation...")
+	var validationErrors []string
+	if target := targets[0]; target.archive {
+		result := validateOne(target)
+		os.Stdout.Write(result.output.Bytes())
+		validationErrors = result.errors
+	} else {
+		validationErrors = doValidation(target.path)
+	}
 
 	if len(validationErrors) > 0 {
 		fmt.Println("\n❌ Validation failed. Please fix the following issues:")
@@ -52,48 +115,120 @@ func runValidate(cmd *cobra.Command, args []string) {
 // doValidation contains the core validation logic, designed to be reusable by other commands.
 // It returns a slice of error strings if validation fails, or an empty slice if it succeeds.
 func doValidation(skillDir string) []string {
+	return validateSkill(skillDir, os.Stdout)
+}
+
+// validateSkill is doValidation writing its progress to out, so that skills
+// can be validated concurrently without interleaving their output.
+func validateSkill(skillDir string, out io.Writer) []string {
 	var allErrors []string
 	manifestPath := filepath.Join(skillDir, "skill.json")
 
 	// --- Pre-check: skill.json must exist ---
 	manifestContent, err := os.ReadFile(manifestPath)
 	if err != nil {
-		return append(allErrors, "Validation failed: skill.json not found in the current directory. Ensure you are in a valid skill directory.")
+		return append(allErrors, fmt.Sprintf("Validation failed: skill.json not found in %s", skillDir))
 	}
 	
-	// --- Schema Validation ---
-	schemaLoader := gojsonschema.NewStringLoader(schema.SkillSchemaContent)
-	manifestLoader := gojsonschema.NewStringLoader(string(manifestContent))
+	// addSection records the outcome of one group of checks: its warnings are
+	// printed, and its errors are listed under its own header.
+	addSection := func(title string, errs, warns []string) {
+		for _, w := range warns {
+			fmt.Fprintf(out, "⚠️  %s\n", w)
+		}
+		if len(errs) == 0 {
+			fmt.Fprintf(out, "✅ %s successful.\n", title)
+			return
+		}
+		allErrors = append(allErrors, fmt.Sprintf("%s failed with the following issues:", title))
+		for _, e := range errs {
+			allErrors = append(allErrors, fmt.Sprintf("  - %s", e))
+		}
+	}
 
-	result, err := gojsonschema.Validate(schemaLoader, manifestLoader)
+	// --- Schema Validation ---
+	schemaErrors, err := validateManifestSchema(manifestContent)
 	if err != nil {
 		return append(allErrors, fmt.Sprintf("Error during validation: %v", err))
 	}
-	if !result.Valid() {
-		allErrors = append(allErrors, "Schema validation failed with the following errors:")
-		for _, desc := range result.Errors() {
-			allErrors = append(allErrors, fmt.Sprintf("  - %s", desc))
-		}
-	} else {
-		fmt.Println("✅ Schema validation successful.")
-	}
+	addSection("Schema validation", schemaErrors, nil)
 
 	// --- Filesystem Checks ---
-	fsErrors := performFileSystemChecks(skillDir, manifestPath)
-	if len(fsErrors) > 0 {
-		if len(allErrors) == 0 {
-			allErrors = append(allErrors, "Filesystem checks failed with the following issues:")
+	addSection("Filesystem checks", performFileSystemChecks(skillDir, manifestPath), nil)
+
+	// --- Icon Checks ---
+	iconErrors, iconWarnings := performIconChecks(skillDir, manifestContent)
+	addSection("Icon checks", iconErrors, iconWarnings)
+
+	// --- Documentation Checks ---
+	docErrors, docWarnings := performMarkdownChecks(skillDir, manifestContent)
+	addSection("Documentation checks", docErrors, docWarnings)
+	if validateTokens {
+		if tokens := skillTokenEstimate(skillDir); tokens >= 0 {
+			fmt.Fprintf(out, "📏 SKILL.md is about %d tokens.\n", tokens)
 		}
-		for _, e := range fsErrors {
-			allErrors = append(allErrors, fmt.Sprintf("  - %s", e))
+	}
+
+	// --- Permission Checks ---
+	addSection("Permission checks", performPermissionChecks(manifestContent), nil)
+
+	// --- Script Permission Audit ---
+	findings, _, err := auditSkill(skillDir, manifestContent)
+	if err != nil {
+		findings = []auditFinding{{File: ".", Severity: "error", Message: fmt.Sprintf("could not audit scripts: %v", err)}}
+	}
+	var auditErrors, auditWarnings []string
+	for _, f := range findings {
+		if f.Severity == "error" {
+			auditErrors = append(auditErrors, f.String())
+		} else {
+			auditWarnings = append(auditWarnings, f.String())
 		}
-	} else {
-		fmt.Println("✅ Filesystem checks successful.")
 	}
+	addSection("Script permission audit", auditErrors, auditWarnings)
+
+	// --- License Checks ---
+	licenseErrors, licenseWarnings := performLicenseChecks(skillDir, manifestContent)
+	addSection("License checks", licenseErrors, licenseWarnings)
+
+	// --- Runtime Checks ---
+	runtimeErrors, runtimeWarnings := performRuntimeChecks(skillDir, manifestContent)
+	addSection("Runtime checks", runtimeErrors, runtimeWarnings)
+
+	// --- Package Dependency Checks ---
+	switch manifestRuntimeType(manifestContent) {
+	case scaffold.RuntimePython:
+		depErrors, depWarnings := performPythonDependencyChecks(skillDir, manifestContent)
+		addSection("Python dependency checks", depErrors, depWarnings)
+	case scaffold.RuntimeNode, scaffold.RuntimeDeno:
+		depErrors, depWarnings := performNpmDependencyChecks(skillDir, manifestContent)
+		addSection("npm dependency checks", depErrors, depWarnings)
+	}
+
+	// --- Version Constraint Checks ---
+	addSection("Version constraint checks", performConstraintChecks(manifestContent), nil)
 
 	return allErrors
 }
 
+// validateManifestSchema validates manifest content against the skill schema and
+// returns a description of each violation. An error is returned only if the
+// content could not be validated at all, e.g. because it is not valid JSON.
+func validateManifestSchema(manifestContent []byte) ([]string, error) {
+	schemaLoader := gojsonschema.NewStringLoader(schema.SkillSchemaContent)
+	manifestLoader := gojsonschema.NewStringLoader(string(manifestContent))
+
+	result, err := gojsonschema.Validate(schemaLoader, manifestLoader)
+	if err != nil {
+		return nil, err
+	}
+	var errors []string
+	for _, desc := range result.Errors() {
+		errors = append(errors, desc.String())
+	}
+	return errors, nil
+}
+
 // performFileSystemChecks ensures files declared in the manifest exist.
 func performFileSystemChecks(skillDir, manifestPath string) []string {
 	var errors []string
@@ -126,3 +261,41 @@ func performFileSystemChecks(skillDir, manifestPath string) []string {
 	}
 	return errors
 }
+
+// skillDependencyPattern matches a "[<author>/]<name>[@<range>]" entry in dependencies.skills.
+var skillDependencyPattern = regexp.MustCompile(`^(?:([a-zA-Z0-9][a-zA-Z0-9_.-]*)/)?([a-z0-9]+(?:-[a-z0-9]+)*)(?:@(.+))?$`)
+
+// performConstraintChecks ensures the runtime version and skill dependency
+// constraints in the manifest are valid SemVer ranges.
+func performConstraintChecks(manifestContent []byte) []string {
+	var errors []string
+	var data struct {
+		Runtime struct {
+			Version string `json:"version"`
+		} `json:"runtime"`
+		Dependencies struct {
+			Skills []string `json:"skills"`
+		} `json:"dependencies"`
+	}
+	json.Unmarshal(manifestContent, &data)
+
+	if data.Runtime.Version != "" {
+		if _, err := semver.ParseRange(data.Runtime.Version); err != nil {
+			errors = append(errors, fmt.Sprintf("'runtime.version' is not a valid version constraint: %v", err))
+		}
+	}
+
+	for _, dep := range data.Dependencies.Skills {
+		m := skillDependencyPattern.FindStringSubmatch(dep)
+		if m == nil {
+			errors = append(errors, fmt.Sprintf("Skill dependency '%s' must have the form '<author>/<name>[@<range>]' (e.g., 'skilzy/pdf-tools@^1.2').", dep))
+			continue
+		}
+		if m[3] != "" {
+			if _, err := semver.ParseRange(m[3]); err != nil {
+				errors = append(errors, fmt.Sprintf("Skill dependency '%s' has an invalid version constraint: %v", dep, err))
+			}
+		}
+	}
+	return errors
+}
@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// validateTarget is a skill to validate: a directory or a package archive.
+type validateTarget struct {
+	display string // the path as the user gave it
+	path    string // absolute path
+	archive bool
+}
+
+// validateResult is the outcome of validating one target.
+type validateResult struct {
+	target validateTarget
+	output bytes.Buffer
+	errors []string
+}
+
+// isSkillArchive reports whether path names a package built by 'skilzy package'.
+func isSkillArchive(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".skill" || ext == ".zip"
+}
+
+// resolveValidateTargets expands the validate command's arguments into skill
+// directories and archives. Glob patterns may match other files and
+// directories, which are skipped; a path given literally must be a skill.
+func resolveValidateTargets(args []string) ([]validateTarget, error) {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	var targets []validateTarget
+	seen := map[string]bool{}
+	for _, arg := range args {
+		matches := []string{arg}
+		isGlob := strings.ContainsAny(arg, "*?[")
+		if isGlob {
+			var err error
+			matches, err = filepath.Glob(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern '%s': %w", arg, err)
+			}
+		}
+
+		found := 0
+		for _, match := range matches {
+			absPath, err := filepath.Abs(match)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path '%s': %w", match, err)
+			}
+			info, err := os.Stat(absPath)
+			if err != nil {
+				return nil, fmt.Errorf("'%s' does not exist", match)
+			}
+
+			target := validateTarget{display: match, path: absPath}
+			switch {
+			case info.IsDir() && fileExists(filepath.Join(absPath, "skill.json")):
+			case !info.IsDir() && isSkillArchive(absPath):
+				target.archive = true
+			case isGlob:
+				continue
+			case info.IsDir():
+				return nil, fmt.Errorf("'%s' is not a skill directory (no skill.json found)", match)
+			default:
+				return nil, fmt.Errorf("'%s' is neither a skill directory nor a .skill/.zip package", match)
+			}
+
+			found++
+			if !seen[absPath] {
+				seen[absPath] = true
+				targets = append(targets, target)
+			}
+		}
+		if found == 0 {
+			return nil, fmt.Errorf("no skills match '%s'", arg)
+		}
+	}
+	return targets, nil
+}
+
+// validateTargets validates targets with up to jobs workers, printing each
+// skill's output as a block, in the order given, as soon as it and every
+// skill before it are done.
+func validateTargets(targets []validateTarget, jobs int) []*validateResult {
+	if jobs < 1 {
+		jobs = 1
+	}
+	results := make([]*validateResult, len(targets))
+	done := make([]chan struct{}, len(targets))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < jobs && w < len(targets); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = validateOne(targets[i])
+				close(done[i])
+			}
+		}()
+	}
+	go func() {
+		for i := range targets {
+			indexes <- i
+		}
+		close(indexes)
+	}()
+
+	for i, result := range done {
+		<-result
+		r := results[i]
+		fmt.Printf("\n🔍 Validating %s (%d/%d)...\n", r.target.display, i+1, len(targets))
+		os.Stdout.Write(r.output.Bytes())
+		if len(r.errors) > 0 {
+			fmt.Println("❌ Validation failed:")
+			for _, e := range r.errors {
+				fmt.Println(e)
+			}
+		} else {
+			fmt.Println("✨ Skill is valid!")
+		}
+	}
+	wg.Wait()
+	return results
+}
+
+// validateOne validates a skill directory, or a package after extracting it
+// to a temporary directory.
+func validateOne(target validateTarget) *validateResult {
+	result := &validateResult{target: target}
+	skillDir := target.path
+	if target.archive {
+		tempDir, err := os.MkdirTemp("", "skilzy-validate-*")
+		if err != nil {
+			result.errors = []string{fmt.Sprintf("Failed to create temporary directory: %v", err)}
+			return result
+		}
+		defer os.RemoveAll(tempDir)
+		skillDir, err = extractSkillArchive(target.path, tempDir)
+		if err != nil {
+			result.errors = []string{fmt.Sprintf("Failed to read package: %v", err)}
+			return result
+		}
+	}
+	result.errors = validateSkill(skillDir, &result.output)
+	return result
+}
+
+// extractSkillArchive extracts a package into dest and returns the directory
+// holding its skill.json. Packages built by 'skilzy package' have a single
+// root folder named after the skill; a package with skill.json at its root is
+// extracted into such a folder so the directory name checks still apply.
+func extractSkillArchive(archivePath, dest string) (string, error) {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	// The shallowest skill.json marks the skill's root
+	manifestEntry := ""
+	for _, file := range reader.File {
+		if path.Base(file.Name) == "skill.json" && (manifestEntry == "" || strings.Count(file.Name, "/") < strings.Count(manifestEntry, "/")) {
+			manifestEntry = file.Name
+		}
+	}
+	if manifestEntry == "" {
+		return "", fmt.Errorf("no skill.json in the package")
+	}
+
+	prefix := ""
+	if root := path.Dir(manifestEntry); root != "." {
+		prefix = root + "/"
+	}
+	skillDir := filepath.Join(dest, filepath.FromSlash(path.Dir(manifestEntry)))
+	if prefix == "" {
+		name, err := archiveSkillName(reader, manifestEntry)
+		if err != nil {
+			return "", err
+		}
+		skillDir = filepath.Join(dest, name)
+	}
+
+	for _, file := range reader.File {
+		if !strings.HasPrefix(file.Name, prefix) || file.FileInfo().IsDir() || !file.Mode().IsRegular() {
+			continue
+		}
+		rel := strings.TrimPrefix(file.Name, prefix)
+		if rel == "" || path.IsAbs(rel) || strings.HasPrefix(path.Clean(rel), "../") || path.Clean(rel) == ".." {
+			return "", fmt.Errorf("unsafe path '%s' in package", file.Name)
+		}
+		target := filepath.Join(skillDir, filepath.FromSlash(path.Clean(rel)))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return "", err
+		}
+		if err := extractZipFile(file, target); err != nil {
+			return "", err
+		}
+	}
+	return skillDir, nil
+}
+
+func extractZipFile(file *zip.File, target string) error {
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// archiveSkillName reads the skill's name from the skill.json entry of a package.
+func archiveSkillName(reader *zip.ReadCloser, manifestEntry string) (string, error) {
+	for _, file := range reader.File {
+		if file.Name != manifestEntry {
+			continue
+		}
+		src, err := file.Open()
+		if err != nil {
+			return "", err
+		}
+		defer src.Close()
+		var data manifestInfo
+		if err := json.NewDecoder(src).Decode(&data); err != nil {
+			return "", fmt.Errorf("failed to parse skill.json: %w", err)
+		}
+		if data.Name == "" || strings.ContainsAny(data.Name, `/\`) || data.Name == "." || data.Name == ".." {
+			return "", fmt.Errorf("skill.json has no valid 'name'")
+		}
+		return data.Name, nil
+	}
+	return "", fmt.Errorf("no skill.json in the package")
+}
+
+// printValidateSummary prints the totals for a multi-skill run and the
+// skills that failed.
+func printValidateSummary(results []*validateResult, elapsed time.Duration) {
+	var failed []string
+	for _, r := range results {
+		if len(r.errors) > 0 {
+			failed = append(failed, r.target.display)
+		}
+	}
+	fmt.Printf("\n📊 %d skill(s) validated in %s: %d valid, %d invalid\n", len(results), elapsed.Round(10*time.Millisecond), len(results)-len(failed), len(failed))
+	for _, name := range failed {
+		fmt.Printf("  ❌ %s\n", name)
+	}
+}
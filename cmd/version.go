@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/skilzy/skilzy-cli/manifest"
+	"github.com/skilzy/skilzy-cli/semver"
+	"github.com/skilzy/skilzy-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bumpPreid     string
+	bumpChangelog bool
+	bumpCommit    bool
+	bumpTag       bool
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Manage the version of the skill in the current directory",
+}
+
+var versionBumpCmd = &cobra.Command{
+	Use:   "bump <major|minor|patch|prerelease>",
+	Short: "Increment the version in skill.json",
+	Long: `Increments the version in skill.json in place, leaving the rest of the file,
+including key order, formatting and unknown fields, untouched.
+
+With --workspace, or when run in a skilzy-workspace.json root, every member
+is bumped; --changed <ref> limits this to skills with changes since the git
+ref. Each skill is committed and tagged separately.
+
+Examples:
+  skilzy version bump patch                        # 1.2.3 -> 1.2.4
+  skilzy version bump minor --changelog            # 1.2.3 -> 1.3.0, adds a CHANGELOG.md section from git history
+  skilzy version bump prerelease --preid beta      # 1.2.3 -> 1.2.4-beta.0
+  skilzy version bump major --tag                  # commits and tags <name>@2.0.0
+  skilzy version bump patch --changed origin/main  # every workspace skill changed on this branch`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"major", "minor", "patch", "prerelease"},
+	Run:       runVersionBump,
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+	versionCmd.AddCommand(versionBumpCmd)
+	versionBumpCmd.Flags().StringVar(&bumpPreid, "preid", "", "Prerelease identifier, e.g. alpha, beta or rc")
+	versionBumpCmd.Flags().BoolVar(&bumpChangelog, "changelog", false, "Add a section for the new version to CHANGELOG.md")
+	versionBumpCmd.Flags().BoolVar(&bumpCommit, "commit", false, "Commit the changed files to git")
+	versionBumpCmd.Flags().BoolVar(&bumpTag, "tag", false, "Commit and create a git tag named <name>@<version>")
+	addWorkspaceFlags(versionBumpCmd)
+}
+
+func runVersionBump(cmd *cobra.Command, args []string) {
+	if usingWorkspace(nil) {
+		if !runWorkspaceBump(args[0]) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	skillDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("✗ Error getting current directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	newVersion, err := bumpSkillVersion(skillDir, args[0], bumpPreid, bumpChangelog, bumpCommit || bumpTag, bumpTag)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("\n✨ Version bumped to %s\n", newVersion)
+}
+
+// bumpSkillVersion increments the version in skillDir's manifest and optionally
+// updates the changelog, commits and tags. It returns the new version.
+func bumpSkillVersion(skillDir, kind, preid string, changelog, commit, tag bool) (string, error) {
+	manifestPath := filepath.Join(skillDir, manifest.FileName)
+	doc, err := manifest.Load(manifestPath)
+	if err != nil {
+		return "", err
+	}
+
+	var data manifestInfo
+	if err := doc.Decode(&data); err != nil {
+		return "", fmt.Errorf("failed to parse skill.json: %w", err)
+	}
+
+	current, err := semver.Parse(data.Version)
+	if err != nil {
+		return "", fmt.Errorf("current version '%s' is not a valid SemVer version", data.Version)
+	}
+	next, err := current.Bump(kind, preid)
+	if err != nil {
+		return "", err
+	}
+	newVersion := next.String()
+	tagName := data.Name + "@" + newVersion
+
+	// Check everything that can fail before changing any file
+	if commit && !utils.IsGitRepo(skillDir) {
+		return "", fmt.Errorf("cannot commit: %s is not inside a git repository", skillDir)
+	}
+	if tag && utils.GitTagExists(skillDir, tagName) {
+		return "", fmt.Errorf("cannot tag: tag '%s' already exists", tagName)
+	}
+	changelogPath := filepath.Join(skillDir, "CHANGELOG.md")
+	changelogText := ""
+	if changelog {
+		// Fill the new section from git history when available
+		notes := ""
+		if utils.IsGitRepo(skillDir) {
+			notes, _, err = generateReleaseNotes(skillDir, utils.LatestGitTag(skillDir, data.Name+"@*"))
+			if err != nil {
+				return "", err
+			}
+		}
+		if changelogText, err = changelogWithSection(changelogPath, newVersion, time.Now(), notes); err != nil {
+			return "", err
+		}
+	}
+
+	if err := doc.Set("version", newVersion); err != nil {
+		return "", err
+	}
+	if err := doc.Save(manifestPath); err != nil {
+		return "", err
+	}
+	fmt.Printf("✅ %s: %s -> %s\n", data.Name, data.Version, newVersion)
+
+	changedFiles := []string{"skill.json"}
+	if changelog {
+		if err := writeChangelog(changelogPath, changelogText); err != nil {
+			return "", err
+		}
+		changedFiles = append(changedFiles, "CHANGELOG.md")
+		fmt.Println("✅ Updated CHANGELOG.md")
+	}
+
+	if !commit {
+		return newVersion, nil
+	}
+	if err := utils.GitCommitFiles(skillDir, fmt.Sprintf("Release %s", tagName), changedFiles...); err != nil {
+		return "", err
+	}
+	fmt.Printf("✅ Committed %s\n", strings.Join(changedFiles, ", "))
+
+	if tag {
+		if err := utils.GitTag(skillDir, tagName, fmt.Sprintf("Release %s", tagName)); err != nil {
+			return "", err
+		}
+		fmt.Printf("✅ Tagged %s\n", tagName)
+	}
+	return newVersion, nil
+}
@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/skilzy/skilzy-cli/manifest"
+	"github.com/skilzy/skilzy-cli/utils"
+	"github.com/skilzy/skilzy-cli/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	workspaceAll     bool
+	workspaceChanged string
+)
+
+// addWorkspaceFlags adds the flags that make a command run on the skills of
+// the workspace around the current directory.
+func addWorkspaceFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&workspaceAll, "workspace", "w", false, "Run on every skill in the "+workspace.FileName+" workspace")
+	cmd.Flags().StringVar(&workspaceChanged, "changed", "", "Run on the workspace skills changed since a git ref, e.g. origin/main")
+}
+
+// usingWorkspace reports whether a command should run on workspace members:
+// when asked to with --workspace or --changed, or when run without paths in
+// a workspace root that is not itself a skill.
+func usingWorkspace(paths []string) bool {
+	if workspaceAll || workspaceChanged != "" {
+		return true
+	}
+	if len(paths) > 0 {
+		return false
+	}
+	return fileExists(workspace.FileName) && !fileExists(manifest.FileName)
+}
+
+// memberWorkspace returns the workspace skillDir is a member of, or nil.
+func memberWorkspace(skillDir string) *workspace.Workspace {
+	ws, err := workspace.Find(skillDir)
+	if err != nil || ws == nil {
+		return nil
+	}
+	members, err := ws.LoadMembers()
+	if err != nil {
+		return nil
+	}
+	for _, m := range members {
+		if m.Dir == skillDir {
+			return ws
+		}
+	}
+	return nil
+}
+
+// selectWorkspaceMembers loads the workspace around the current directory
+// and returns its members in dependency order, along with those selected by
+// --changed (all of them without it).
+func selectWorkspaceMembers() (ws *workspace.Workspace, selected, all []*workspace.Member, err error) {
+	ws, err = workspace.Find(".")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if ws == nil {
+		return nil, nil, nil, fmt.Errorf("no %s found in this directory or its parents", workspace.FileName)
+	}
+	members, err := ws.LoadMembers()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	all, err = workspace.Order(members)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	selected = all
+	if workspaceChanged != "" {
+		files, err := utils.GitChangedFiles(ws.Root, workspaceChanged)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		selected = workspace.Changed(all, files)
+		fmt.Printf("🗂️  Workspace %s: %d of %d skill(s) changed since %s\n", ws.Root, len(selected), len(all), workspaceChanged)
+	} else {
+		fmt.Printf("🗂️  Workspace %s: %d skill(s)\n", ws.Root, len(all))
+	}
+	return ws, selected, all, nil
+}
+
+// memberSkipped is returned by a runMembers step for a member that needed
+// no work, such as a version that is already published.
+type memberSkipped struct{ reason string }
+
+func (s memberSkipped) Error() string { return s.reason }
+
+// runMembers runs step on each member in order. When a member fails, the
+// members depending on it are skipped. It prints a summary and reports
+// whether every member succeeded.
+func runMembers(action string, selected, all []*workspace.Member, step func(m *workspace.Member) error) bool {
+	start := time.Now()
+	failed := map[*workspace.Member]string{}
+	var succeeded, skipped, failures []string
+
+	for _, m := range selected {
+		if m.Version != "" {
+			fmt.Printf("\n📁 %s@%s (%s)\n", m.Name, m.Version, m.Path)
+		} else {
+			fmt.Printf("\n📁 %s (%s)\n", m.Name, m.Path)
+		}
+		if reason, ok := failed[m]; ok {
+			fmt.Printf("⏭️  Skipped: %s\n", reason)
+			failures = append(failures, m.Name)
+			continue
+		}
+
+		err := step(m)
+		if skip, ok := err.(memberSkipped); ok {
+			fmt.Printf("⏭️  %s\n", skip.reason)
+			skipped = append(skipped, m.Name)
+			continue
+		}
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			failures = append(failures, m.Name)
+			for _, dependent := range workspace.Dependents(all, []*workspace.Member{m}) {
+				if _, ok := failed[dependent]; !ok {
+					failed[dependent] = fmt.Sprintf("depends on %s, which failed", m.Name)
+				}
+			}
+			continue
+		}
+		succeeded = append(succeeded, m.Name)
+	}
+
+	fmt.Printf("\n📊 %s %d of %d skill(s) in %s", action, len(succeeded), len(selected), time.Since(start).Round(10*time.Millisecond))
+	if len(skipped) > 0 {
+		fmt.Printf(", %d skipped", len(skipped))
+	}
+	fmt.Println()
+	for _, name := range failures {
+		fmt.Printf("  ❌ %s\n", name)
+	}
+	return len(failures) == 0
+}
+
+// runWorkspaceValidate validates the selected workspace members
+// concurrently and checks the version ranges between them.
+func runWorkspaceValidate() bool {
+	_, selected, all, err := selectWorkspaceMembers()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return false
+	}
+	if len(selected) == 0 {
+		fmt.Println("\n✨ No skills to validate.")
+		return true
+	}
+
+	var targets []validateTarget
+	for _, m := range selected {
+		targets = append(targets, validateTarget{display: m.Path, path: m.Dir})
+	}
+	start := time.Now()
+	results := validateTargets(targets, validateJobs)
+
+	ok := checkWorkspaceDependencies(all)
+	printValidateSummary(results, time.Since(start))
+	for _, r := range results {
+		if len(r.errors) > 0 {
+			ok = false
+		}
+	}
+	return ok
+}
+
+// checkWorkspaceDependencies prints the dependency ranges between members
+// that are not satisfied, and reports whether all of them are.
+func checkWorkspaceDependencies(members []*workspace.Member) bool {
+	problems := workspace.CheckDependencies(members)
+	if len(problems) == 0 {
+		return true
+	}
+	fmt.Println("\n❌ Workspace dependency checks failed:")
+	for _, p := range problems {
+		fmt.Printf("  - %s\n", p)
+	}
+	return false
+}
+
+// runWorkspacePackage validates and packages the selected workspace members
+// into the workspace's output directory.
+func runWorkspacePackage(cmd *cobra.Command) {
+	if outputName != "" {
+		fmt.Println("❌ --output-name cannot be used with a workspace; packages are named after each skill.")
+		os.Exit(1)
+	}
+	ws, selected, all, err := selectWorkspaceMembers()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	finalOutputDir := ws.OutputPath()
+	if cmd.Flags().Changed("output-dir") {
+		finalOutputDir = filepath.Join(ws.Root, outputDir)
+	}
+	if !checkWorkspaceDependencies(all) {
+		os.Exit(1)
+	}
+
+	ok := runMembers("Packaged", selected, all, func(m *workspace.Member) error {
+		if err := validateMember(m); err != nil {
+			return err
+		}
+		archivePath, err := packageSkill(m.Dir, finalOutputDir, "")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Packaged to %s\n", archivePath)
+		return nil
+	})
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// validateMember validates a member quietly, printing the full report only
+// if it is invalid.
+func validateMember(m *workspace.Member) error {
+	var output bytes.Buffer
+	validationErrors := validateSkill(m.Dir, &output)
+	if len(validationErrors) > 0 {
+		os.Stdout.Write(output.Bytes())
+		fmt.Println(strings.Join(validationErrors, "\n"))
+		return fmt.Errorf("validation failed")
+	}
+	fmt.Println("✨ Skill is valid.")
+	return nil
+}
+
+// runWorkspacePublish publishes the selected workspace members whose
+// versions are not yet in the registry, dependencies first.
+func runWorkspacePublish(client *utils.SkilzyClient) bool {
+	_, selected, all, err := selectWorkspaceMembers()
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return false
+	}
+	if !checkWorkspaceDependencies(all) {
+		return false
+	}
+	tempDir, err := os.MkdirTemp("", "skilzy-publish-*")
+	if err != nil {
+		fmt.Printf("✗ Failed to create temporary directory: %v\n", err)
+		return false
+	}
+	defer os.RemoveAll(tempDir)
+
+	action := "Published"
+	if publishDryRun {
+		action = "Checked"
+	}
+	return runMembers(action, selected, all, func(m *workspace.Member) error {
+		if m.Err != nil {
+			// Without a readable manifest there is no version to look up
+			if err := validateMember(m); err != nil {
+				return err
+			}
+			return m.Err
+		}
+		exists, err := client.SkillVersionExists(publishOrg, m.Name, m.Version)
+		if err != nil {
+			return fmt.Errorf("could not check whether %s@%s is already published: %w", m.Name, m.Version, err)
+		}
+		if exists {
+			return memberSkipped{fmt.Sprintf("%s@%s is already published.", m.Name, m.Version)}
+		}
+		if err := validateMember(m); err != nil {
+			return err
+		}
+		data, err := readManifestInfo(m.Dir)
+		if err != nil {
+			return err
+		}
+		packagePath := filepath.Join(tempDir, data.archiveName())
+		if err := createPackage(m.Dir, data, packagePath, ""); err != nil {
+			return err
+		}
+		if !publishPackage(client, packagePath) {
+			return fmt.Errorf("publishing %s@%s failed", m.Name, m.Version)
+		}
+		return nil
+	})
+}
+
+// runWorkspaceBump bumps the version of each selected workspace member and
+// warns about dependency ranges between members that no longer match.
+func runWorkspaceBump(kind string) bool {
+	ws, selected, all, err := selectWorkspaceMembers()
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return false
+	}
+	ok := runMembers("Bumped", selected, all, func(m *workspace.Member) error {
+		_, err := bumpSkillVersion(m.Dir, kind, bumpPreid, bumpChangelog, bumpCommit || bumpTag, bumpTag)
+		return err
+	})
+
+	if members, err := ws.LoadMembers(); err == nil {
+		for _, p := range workspace.CheckDependencies(members) {
+			fmt.Printf("⚠️  %s\n", p)
+		}
+	}
+	return ok
+}
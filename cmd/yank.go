@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	yankOrg    string
+	yankReason string
+	yankUndo   bool
+)
+
+var yankCmd = &cobra.Command{
+	Use:   "yank <skill>@<version>",
+	Short: "Pull back a broken release without deleting it",
+	Long: `Yanks a published version. A yanked version is hidden from search results and
+is never resolved as "latest", but can still be downloaded by its exact version
+so existing users are not broken.
+
+Examples:
+  skilzy yank my-skill@1.2.0 --reason "corrupts output files"
+  skilzy yank my-skill@1.2.0 --undo`,
+	Args: cobra.ExactArgs(1),
+	Run:  runYank,
+}
+
+func init() {
+	rootCmd.AddCommand(yankCmd)
+	yankCmd.Flags().StringVar(&yankOrg, "org", "", "The organization the skill is published under")
+	yankCmd.Flags().StringVar(&yankReason, "reason", "", "Why the version is being yanked")
+	yankCmd.Flags().BoolVar(&yankUndo, "undo", false, "Restore a previously yanked version")
+}
+
+func runYank(cmd *cobra.Command, args []string) {
+	name, version := splitSkillRef(args[0])
+	if version == "" {
+		fmt.Println("✗ A version is required, e.g. 'skilzy yank my-skill@1.2.0'.")
+		os.Exit(1)
+	}
+
+	client := newAuthenticatedClient()
+	if err := client.YankVersion(yankOrg, name, version, yankReason, yankUndo); err != nil {
+		fmt.Printf("✗ Failed to update %s@%s: %v\n", name, version, err)
+		os.Exit(1)
+	}
+
+	if yankUndo {
+		fmt.Printf("✓ Restored %s@%s\n", name, version)
+		return
+	}
+	fmt.Printf("✓ Yanked %s@%s\n", name, version)
+	fmt.Println("  It is hidden from search and 'latest', but can still be installed by exact version.")
+}
@@ -0,0 +1,79 @@
+package icon
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// gridSize is the number of cells along each side of a generated icon. The
+// left half of the grid is mirrored onto the right.
+const gridSize = 5
+
+// Generate returns an identicon-style SVG icon for a skill: a symmetric
+// pattern of cells in a color, both derived from the name. The same name
+// always gives the same icon.
+func Generate(name string) string {
+	sum := sha256.Sum256([]byte(name))
+
+	// The first two bytes choose the hue, the rest the cells
+	hue := float64(int(sum[0])<<8|int(sum[1])) / 65536 * 360
+	foreground := hslToHex(hue, 0.55, 0.45)
+	background := hslToHex(hue, 0.45, 0.93)
+
+	const cell = 40
+	const padding = cell / 2
+	const size = gridSize*cell + 2*padding
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, size, size, size, size)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" rx="%d" fill="%s"/>`, size, size, cell/2, background)
+	fmt.Fprintf(&b, `<g fill="%s">`, foreground)
+	columns := (gridSize + 1) / 2
+	filled := 0
+	for row := 0; row < gridSize; row++ {
+		for column := 0; column < columns; column++ {
+			bit := row*columns + column
+			if sum[2+bit/8]>>(bit%8)&1 == 0 {
+				continue
+			}
+			filled++
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d"/>`, padding+column*cell, padding+row*cell, cell, cell)
+			if mirror := gridSize - 1 - column; mirror != column {
+				fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d"/>`, padding+mirror*cell, padding+row*cell, cell, cell)
+			}
+		}
+	}
+	// An empty grid would give a blank icon; fill the center instead
+	if filled == 0 {
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d"/>`, padding+gridSize/2*cell, padding+gridSize/2*cell, cell, cell)
+	}
+	b.WriteString("</g></svg>\n")
+	return b.String()
+}
+
+// hslToHex converts a color from HSL, with hue in degrees and saturation and
+// lightness between 0 and 1, to a "#rrggbb" string.
+func hslToHex(hue, saturation, lightness float64) string {
+	chroma := (1 - math.Abs(2*lightness-1)) * saturation
+	x := chroma * (1 - math.Abs(math.Mod(hue/60, 2)-1))
+	var r, g, b float64
+	switch {
+	case hue < 60:
+		r, g, b = chroma, x, 0
+	case hue < 120:
+		r, g, b = x, chroma, 0
+	case hue < 180:
+		r, g, b = 0, chroma, x
+	case hue < 240:
+		r, g, b = 0, x, chroma
+	case hue < 300:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+	m := lightness - chroma/2
+	channel := func(v float64) int { return int(math.Round((v + m) * 255)) }
+	return fmt.Sprintf("#%02x%02x%02x", channel(r), channel(g), channel(b))
+}
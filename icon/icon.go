@@ -0,0 +1,253 @@
+// Package icon inspects skill icons and generates default ones: an icon must
+// be a square-ish SVG or PNG of reasonable size, and an SVG must not run
+// scripts or load anything from outside the file.
+package icon
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image/png"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Supported icon formats.
+const (
+	FormatSVG = "svg"
+	FormatPNG = "png"
+)
+
+// Limits for icons. Icons are displayed square, at up to RecommendedSize
+// pixels.
+const (
+	MaxFileSize         = 1 << 20   // larger icons are rejected
+	RecommendedFileSize = 256 << 10 // larger icons are accepted with a warning
+	MinSize             = 64        // smallest width and height of a PNG
+	RecommendedSize     = 256       // smallest recommended width and height of a PNG
+	MaxAspectRatio      = 2.0       // widest (or tallest) accepted shape
+)
+
+// Info describes an icon file.
+type Info struct {
+	Format string
+	// Width and Height are in pixels for PNGs and in user units (taken from
+	// the viewBox, or else the width and height attributes) for SVGs. They
+	// are zero if an SVG does not declare its size.
+	Width, Height float64
+	Size          int
+	// Unsafe lists the scripts and external references found in an SVG.
+	Unsafe []string
+}
+
+// AspectRatio returns the ratio of the longer side to the shorter one, or 0
+// if the dimensions are unknown.
+func (i *Info) AspectRatio() float64 {
+	if i.Width <= 0 || i.Height <= 0 {
+		return 0
+	}
+	if i.Width > i.Height {
+		return i.Width / i.Height
+	}
+	return i.Height / i.Width
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// Inspect determines the format, dimensions and size of an icon from its
+// content, and lists unsafe SVG content.
+func Inspect(content []byte) (*Info, error) {
+	info := &Info{Size: len(content)}
+	if bytes.HasPrefix(content, pngSignature) {
+		config, err := png.DecodeConfig(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("invalid PNG: %w", err)
+		}
+		info.Format = FormatPNG
+		info.Width, info.Height = float64(config.Width), float64(config.Height)
+		return info, nil
+	}
+	if format := sniffOther(content); format != "" {
+		return nil, fmt.Errorf("%s images are not supported; use SVG or PNG", format)
+	}
+	if err := inspectSVG(content, info); err != nil {
+		return nil, err
+	}
+	info.Format = FormatSVG
+	return info, nil
+}
+
+// sniffOther names common image formats that are not supported as icons.
+func sniffOther(content []byte) string {
+	switch {
+	case bytes.HasPrefix(content, []byte("\xff\xd8\xff")):
+		return "JPEG"
+	case bytes.HasPrefix(content, []byte("GIF8")):
+		return "GIF"
+	case len(content) >= 12 && string(content[:4]) == "RIFF" && string(content[8:12]) == "WEBP":
+		return "WebP"
+	case bytes.HasPrefix(content, []byte("\x00\x00\x01\x00")):
+		return "ICO"
+	case bytes.HasPrefix(content, []byte("BM")):
+		return "BMP"
+	}
+	return ""
+}
+
+var (
+	// externalURL matches url(...) references in CSS, capturing the target
+	externalURL = regexp.MustCompile(`(?i)url\(\s*['"]?\s*([^'")\s]*)`)
+	cssImport   = regexp.MustCompile(`(?i)@import`)
+	lengthValue = regexp.MustCompile(`^\s*([0-9.]+)\s*(px)?\s*$`)
+)
+
+// inspectSVG parses an SVG document, reading its dimensions into info and
+// recording scripts, event handlers and external references as unsafe.
+func inspectSVG(content []byte, info *Info) error {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = false
+	sawRoot := false
+	inStyle := false
+	unsafe := map[string]bool{}
+	addUnsafe := func(format string, args ...interface{}) {
+		message := fmt.Sprintf(format, args...)
+		if !unsafe[message] {
+			unsafe[message] = true
+			info.Unsafe = append(info.Unsafe, message)
+		}
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if !sawRoot {
+				return fmt.Errorf("not an SVG or PNG image")
+			}
+			return fmt.Errorf("invalid SVG: %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := strings.ToLower(t.Name.Local)
+			if !sawRoot {
+				if name != "svg" {
+					return fmt.Errorf("not an SVG or PNG image (root element is <%s>)", t.Name.Local)
+				}
+				sawRoot = true
+				readSVGSize(t, info)
+			}
+			switch name {
+			case "script":
+				addUnsafe("<script> element")
+			case "foreignobject":
+				addUnsafe("<foreignObject> element, which can embed HTML")
+			case "style":
+				inStyle = true
+			}
+			for _, attr := range t.Attr {
+				checkAttribute(t.Name.Local, attr, addUnsafe)
+			}
+		case xml.EndElement:
+			if strings.EqualFold(t.Name.Local, "style") {
+				inStyle = false
+			}
+		case xml.CharData:
+			if inStyle {
+				checkCSS("<style>", string(t), addUnsafe)
+			}
+		case xml.ProcInst:
+			if t.Target == "xml-stylesheet" {
+				addUnsafe("external stylesheet (<?xml-stylesheet?>)")
+			}
+		case xml.Directive:
+			if bytes.Contains(t, []byte("ENTITY")) {
+				addUnsafe("entity declaration in the DOCTYPE")
+			}
+		}
+	}
+	if !sawRoot {
+		return fmt.Errorf("not an SVG or PNG image")
+	}
+	return nil
+}
+
+func checkAttribute(element string, attr xml.Attr, addUnsafe func(string, ...interface{})) {
+	name := strings.ToLower(attr.Name.Local)
+	value := strings.TrimSpace(attr.Value)
+	switch {
+	case strings.HasPrefix(name, "on"):
+		addUnsafe("'%s' event handler on <%s>", attr.Name.Local, element)
+	case name == "href":
+		if isExternal(value) {
+			addUnsafe("external reference '%s' on <%s>", value, element)
+		}
+	case name == "style":
+		checkCSS(fmt.Sprintf("<%s> style", element), value, addUnsafe)
+	default:
+		// Presentation attributes such as fill="url(#gradient)"
+		if strings.Contains(strings.ToLower(value), "url(") {
+			checkCSS(fmt.Sprintf("'%s' on <%s>", attr.Name.Local, element), value, addUnsafe)
+		}
+	}
+}
+
+func checkCSS(where, css string, addUnsafe func(string, ...interface{})) {
+	if cssImport.MatchString(css) {
+		addUnsafe("@import in %s", where)
+	}
+	for _, match := range externalURL.FindAllStringSubmatch(css, -1) {
+		if isExternal(match[1]) {
+			addUnsafe("external reference '%s' in %s", match[1], where)
+		}
+	}
+}
+
+// isExternal reports whether a reference leaves the document: anything but a
+// fragment ("#id") or inline data ("data:...").
+func isExternal(ref string) bool {
+	return ref != "" && !strings.HasPrefix(ref, "#") && !strings.HasPrefix(strings.ToLower(ref), "data:")
+}
+
+// readSVGSize reads the dimensions of the root <svg> element, preferring the
+// viewBox, which determines the shape of the rendered icon.
+func readSVGSize(root xml.StartElement, info *Info) {
+	var width, height, viewBox string
+	for _, attr := range root.Attr {
+		switch attr.Name.Local {
+		case "width":
+			width = attr.Value
+		case "height":
+			height = attr.Value
+		case "viewBox":
+			viewBox = attr.Value
+		}
+	}
+	if fields := strings.FieldsFunc(viewBox, func(r rune) bool { return r == ' ' || r == ',' }); len(fields) == 4 {
+		w, errW := strconv.ParseFloat(fields[2], 64)
+		h, errH := strconv.ParseFloat(fields[3], 64)
+		if errW == nil && errH == nil && w > 0 && h > 0 {
+			info.Width, info.Height = w, h
+			return
+		}
+	}
+	w, okW := parseLength(width)
+	h, okH := parseLength(height)
+	if okW && okH {
+		info.Width, info.Height = w, h
+	}
+}
+
+// parseLength parses a unitless or pixel length; other units and percentages
+// say nothing about the icon's shape without a viewBox.
+func parseLength(s string) (float64, bool) {
+	match := lengthValue.FindStringSubmatch(s)
+	if match == nil {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	return value, err == nil && value > 0
+}
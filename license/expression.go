@@ -0,0 +1,242 @@
+package license
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Expression is a parsed SPDX license expression. A leaf names one license,
+// optionally with an exception; other nodes combine their operands with AND
+// or OR.
+type Expression struct {
+	Op        string // "AND", "OR", or "" for a leaf
+	License   string // canonical identifier, including a trailing "+" if given
+	Exception string // canonical exception identifier after WITH, if any
+	Operands  []*Expression
+}
+
+var (
+	idPattern     = regexp.MustCompile(`^[A-Za-z0-9.-]+\+?$`)
+	refPattern    = regexp.MustCompile(`^(?:DocumentRef-[A-Za-z0-9.-]+:)?LicenseRef-[A-Za-z0-9.-]+$`)
+	tokenPattern  = regexp.MustCompile(`\(|\)|[^\s()]+`)
+	lowerOperator = map[string]string{"and": "AND", "or": "OR", "with": "WITH"}
+)
+
+// IsCustom reports whether id is a user-defined "LicenseRef-" reference
+// rather than an identifier from the SPDX list.
+func IsCustom(id string) bool {
+	return refPattern.MatchString(id)
+}
+
+// ParseExpression parses an SPDX license expression such as "MIT",
+// "Apache-2.0 OR MIT" or "GPL-2.0-or-later WITH Classpath-exception-2.0".
+// Identifiers are matched without regard to case; operators must be upper case.
+func ParseExpression(s string) (*Expression, error) {
+	p := &exprParser{tokens: tokenPattern.FindAllString(s, -1)}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("license expression is empty")
+	}
+	expr, err := p.parseOr()
+	if err == nil && p.pos < len(p.tokens) {
+		token := p.tokens[p.pos]
+		if upper := lowerOperator[token]; upper != "" {
+			err = fmt.Errorf("operators must be upper case: use '%s' instead of '%s'", upper, token)
+		} else {
+			err = fmt.Errorf("unexpected '%s'", token)
+		}
+	}
+	if err != nil {
+		// A license name written out in words, such as "Apache License 2.0"
+		if suggestion := Suggest(s); suggestion != "" && strings.Contains(strings.TrimSpace(s), " ") {
+			return nil, fmt.Errorf("'%s' is not an SPDX license identifier; did you mean '%s'?", strings.TrimSpace(s), suggestion)
+		}
+		return nil, err
+	}
+	return expr, nil
+}
+
+type exprParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *exprParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *exprParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *exprParser) parseOr() (*Expression, error) {
+	return p.parseBinary("OR", p.parseAnd)
+}
+
+func (p *exprParser) parseAnd() (*Expression, error) {
+	return p.parseBinary("AND", p.parseWith)
+}
+
+// parseBinary parses operands joined by op, which binds looser than the
+// operator parsed by operand.
+func (p *exprParser) parseBinary(op string, operand func() (*Expression, error)) (*Expression, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	operands := []*Expression{first}
+	for p.peek() == op {
+		p.next()
+		next, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, next)
+	}
+	if len(operands) == 1 {
+		return first, nil
+	}
+	return &Expression{Op: op, Operands: operands}, nil
+}
+
+func (p *exprParser) parseWith() (*Expression, error) {
+	expr, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != "WITH" {
+		return expr, nil
+	}
+	p.next()
+	if expr.Op != "" {
+		return nil, fmt.Errorf("WITH must follow a single license, not a parenthesized expression")
+	}
+	token := p.next()
+	if token == "" {
+		return nil, fmt.Errorf("missing exception after WITH")
+	}
+	exception, ok := LookupException(token)
+	if !ok {
+		return nil, fmt.Errorf("unknown license exception '%s'", token)
+	}
+	expr.Exception = exception
+	return expr, nil
+}
+
+func (p *exprParser) parsePrimary() (*Expression, error) {
+	token := p.next()
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("expression ends where a license was expected")
+	case token == "(":
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing ')'")
+		}
+		return expr, nil
+	case token == ")" || token == "AND" || token == "OR" || token == "WITH":
+		return nil, fmt.Errorf("unexpected '%s' where a license was expected", token)
+	case lowerOperator[token] != "":
+		return nil, fmt.Errorf("operators must be upper case: use '%s' instead of '%s'", lowerOperator[token], token)
+	}
+	return parseLicenseID(token)
+}
+
+func parseLicenseID(token string) (*Expression, error) {
+	if IsCustom(token) {
+		return &Expression{License: token}, nil
+	}
+	if !idPattern.MatchString(token) {
+		return nil, fmt.Errorf("'%s' is not a valid license identifier", token)
+	}
+	base, orLater := strings.CutSuffix(token, "+")
+	canonical, ok := Lookup(base)
+	if !ok {
+		// "GPL-3.0+" was an identifier of its own before the "+" operator
+		canonical, ok = Lookup(token)
+		orLater = false
+	}
+	if !ok {
+		if suggestion := Suggest(token); suggestion != "" {
+			return nil, fmt.Errorf("unknown license '%s'; did you mean '%s'?", token, suggestion)
+		}
+		return nil, fmt.Errorf("unknown license '%s'; see https://spdx.org/licenses/ for valid identifiers", token)
+	}
+	if orLater {
+		canonical += "+"
+	}
+	return &Expression{License: canonical}, nil
+}
+
+// Licenses returns the licenses named in the expression, in order and without
+// duplicates.
+func (e *Expression) Licenses() []string {
+	var ids []string
+	seen := map[string]bool{}
+	var walk func(*Expression)
+	walk = func(e *Expression) {
+		if e.Op == "" {
+			if !seen[e.License] {
+				seen[e.License] = true
+				ids = append(ids, e.License)
+			}
+			return
+		}
+		for _, operand := range e.Operands {
+			walk(operand)
+		}
+	}
+	walk(e)
+	return ids
+}
+
+// Deprecated returns the deprecated license and exception identifiers in the
+// expression.
+func (e *Expression) Deprecated() []string {
+	var ids []string
+	var walk func(*Expression)
+	walk = func(e *Expression) {
+		if e.Op == "" {
+			if IsDeprecated(e.License) {
+				ids = append(ids, e.License)
+			}
+			if e.Exception != "" && IsDeprecated(e.Exception) {
+				ids = append(ids, e.Exception)
+			}
+			return
+		}
+		for _, operand := range e.Operands {
+			walk(operand)
+		}
+	}
+	walk(e)
+	return ids
+}
+
+// String returns the expression with canonical identifiers, adding
+// parentheses only where precedence requires them.
+func (e *Expression) String() string {
+	if e.Op == "" {
+		if e.Exception != "" {
+			return e.License + " WITH " + e.Exception
+		}
+		return e.License
+	}
+	parts := make([]string, len(e.Operands))
+	for i, operand := range e.Operands {
+		parts[i] = operand.String()
+		// AND binds tighter than OR, so only an OR inside an AND needs parentheses
+		if operand.Op == "OR" && e.Op == "AND" {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, " "+e.Op+" ")
+}
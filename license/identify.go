@@ -0,0 +1,116 @@
+package license
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// fingerprint recognizes a license's text: all of its phrases must appear and
+// none of its exclusions.
+type fingerprint struct {
+	phrases    []string
+	exclusions []string
+}
+
+// fingerprints are keyed like the embedded texts (see textKey). Phrases are
+// lower case with single spaces.
+var fingerprints = map[string]fingerprint{
+	"MIT":          {phrases: []string{"permission is hereby granted, free of charge, to any person obtaining a copy", "the above copyright notice and this permission notice shall be included"}},
+	"MIT-0":        {phrases: []string{"permission is hereby granted, free of charge, to any person obtaining a copy"}, exclusions: []string{"the above copyright notice and this permission notice shall be included"}},
+	"Apache-2.0":   {phrases: []string{"apache license", "version 2.0, january 2004"}},
+	"BSD-2-Clause": {phrases: []string{"redistribution and use in source and binary forms", "redistributions in binary form must reproduce"}, exclusions: []string{"neither the name of", "may be used to endorse or promote"}},
+	"BSD-3-Clause": {phrases: []string{"redistribution and use in source and binary forms", "redistributions in binary form must reproduce", "may be used to endorse or promote"}},
+	"ISC":          {phrases: []string{"permission to use, copy, modify, and/or distribute this software for any purpose with or without fee is hereby granted, provided that"}},
+	"0BSD":         {phrases: []string{"permission to use, copy, modify, and/or distribute this software for any purpose with or without fee is hereby granted."}},
+	"MPL-2.0":      {phrases: []string{"mozilla public license version 2.0"}},
+	"GPL-2.0":      {phrases: []string{"gnu general public license version 2, june 1991"}},
+	"GPL-3.0":      {phrases: []string{"gnu general public license version 3, 29 june 2007"}},
+	"LGPL-2.1":     {phrases: []string{"gnu lesser general public license version 2.1, february 1999"}},
+	"LGPL-3.0":     {phrases: []string{"gnu lesser general public license version 3, 29 june 2007"}},
+	"AGPL-3.0":     {phrases: []string{"gnu affero general public license version 3, 19 november 2007"}},
+	"Unlicense":    {phrases: []string{"this is free and unencumbered software released into the public domain"}},
+	"CC0-1.0":      {phrases: []string{"cc0 1.0 universal"}},
+	"CC-BY-4.0":    {phrases: []string{"attribution 4.0 international"}},
+}
+
+var (
+	commentMarker = regexp.MustCompile(`(?m)^\s*(?:\*|#|//|/\*|\*/)`)
+	whitespace    = regexp.MustCompile(`\s+`)
+)
+
+func normalizeText(text string) string {
+	text = commentMarker.ReplaceAllString(text, " ")
+	return whitespace.ReplaceAllString(strings.ToLower(text), " ")
+}
+
+// Identify returns the licenses whose text appears in text, by their base
+// identifiers (e.g. "GPL-3.0" for both GPL-3.0-only and GPL-3.0-or-later).
+func Identify(text string) []string {
+	normalized := normalizeText(text)
+	var ids []string
+	for id, fp := range fingerprints {
+		if fp.matches(normalized) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (fp fingerprint) matches(normalized string) bool {
+	for _, phrase := range fp.phrases {
+		if !strings.Contains(normalized, phrase) {
+			return false
+		}
+	}
+	for _, exclusion := range fp.exclusions {
+		if strings.Contains(normalized, exclusion) {
+			return false
+		}
+	}
+	return true
+}
+
+// Recognizable reports whether Matches can check text against license id.
+func Recognizable(id string) bool {
+	_, ok := fingerprints[textKey(id)]
+	return ok
+}
+
+// Matches reports whether text contains the text of license id. It is only
+// meaningful if Recognizable(id) is true.
+func Matches(id, text string) bool {
+	fp, ok := fingerprints[textKey(id)]
+	return ok && fp.matches(normalizeText(text))
+}
+
+// MissingTexts returns the part of the expression whose license texts text
+// lacks, or nil if text covers it: every recognizable license of an AND must
+// be present, and one alternative of an OR is enough. Licenses that are not
+// recognizable are assumed to be present.
+func (e *Expression) MissingTexts(text string) *Expression {
+	if e.Op == "" {
+		if Recognizable(e.License) && !Matches(e.License, text) {
+			return e
+		}
+		return nil
+	}
+	var missing []*Expression
+	for _, operand := range e.Operands {
+		m := operand.MissingTexts(text)
+		if m == nil && e.Op == "OR" {
+			return nil
+		}
+		if m != nil {
+			missing = append(missing, m)
+		}
+	}
+	switch len(missing) {
+	case 0:
+		return nil
+	case 1:
+		return missing[0]
+	}
+	return &Expression{Op: e.Op, Operands: missing}
+}
@@ -0,0 +1,213 @@
+// Package license knows the SPDX license list: it validates license
+// identifiers and expressions such as "MIT OR Apache-2.0", renders the full
+// texts of common licenses, and recognizes those texts in license files.
+package license
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed spdx-licenses.txt spdx-exceptions.txt
+var spdxLists embed.FS
+
+//go:embed texts
+var texts embed.FS
+
+// entry is an identifier on the SPDX list.
+type entry struct {
+	ID         string
+	Deprecated bool
+}
+
+var (
+	licenses   = loadList("spdx-licenses.txt")
+	exceptions = loadList("spdx-exceptions.txt")
+)
+
+// loadList reads an embedded list, keyed by lower-case identifier.
+func loadList(name string) map[string]entry {
+	content, err := spdxLists.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+	list := map[string]entry{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		list[strings.ToLower(fields[0])] = entry{ID: fields[0], Deprecated: len(fields) > 1 && fields[1] == "deprecated"}
+	}
+	return list
+}
+
+// Lookup returns the canonical form of a license identifier, matched without
+// regard to case, and whether the SPDX list has it.
+func Lookup(id string) (string, bool) {
+	e, ok := licenses[strings.ToLower(id)]
+	return e.ID, ok
+}
+
+// LookupException is Lookup for license exceptions, as used after WITH.
+func LookupException(id string) (string, bool) {
+	e, ok := exceptions[strings.ToLower(id)]
+	return e.ID, ok
+}
+
+// IsDeprecated reports whether the SPDX list marks a license or exception
+// identifier as deprecated.
+func IsDeprecated(id string) bool {
+	return licenses[strings.ToLower(id)].Deprecated || exceptions[strings.ToLower(id)].Deprecated
+}
+
+// Replacement suggests the identifier to use instead of a deprecated one, such
+// as "GPL-3.0-only" for "GPL-3.0", or "" if there is no direct replacement.
+func Replacement(id string) string {
+	base, orLater := strings.CutSuffix(id, "+")
+	suffix := "-only"
+	if orLater {
+		suffix = "-or-later"
+	}
+	if replacement, ok := Lookup(base + suffix); ok {
+		return replacement
+	}
+	return ""
+}
+
+var (
+	suggestionNoise   = regexp.MustCompile(`\b(?:the|license|licence|version|only)\b|[^a-z0-9.+]`)
+	suggestionVersion = regexp.MustCompile(`v(\d)`)
+	suggestionZero    = regexp.MustCompile(`(\d)\.0\b`)
+)
+
+// suggestions maps loosely normalized names ("apache2", "gpl3") to identifiers.
+var suggestions = func() map[string]string {
+	ids := make([]entry, 0, len(licenses))
+	for _, e := range licenses {
+		ids = append(ids, e)
+	}
+	// Prefer current identifiers, then the shortest ("GPL-3.0-only" over "GPL-3.0-or-later")
+	sort.Slice(ids, func(i, j int) bool {
+		if ids[i].Deprecated != ids[j].Deprecated {
+			return !ids[i].Deprecated
+		}
+		if len(ids[i].ID) != len(ids[j].ID) {
+			return len(ids[i].ID) < len(ids[j].ID)
+		}
+		return ids[i].ID < ids[j].ID
+	})
+	m := map[string]string{}
+	for _, e := range ids {
+		key := suggestionKey(e.ID)
+		if _, ok := m[key]; !ok {
+			m[key] = e.ID
+		}
+	}
+	return m
+}()
+
+func suggestionKey(name string) string {
+	key := strings.ToLower(strings.ReplaceAll(name, "-", " "))
+	key = suggestionNoise.ReplaceAllString(key, "")
+	key = suggestionVersion.ReplaceAllString(key, "$1")
+	return suggestionZero.ReplaceAllString(key, "$1")
+}
+
+// Suggest returns the identifier a misspelled license name most likely refers
+// to, e.g. "Apache-2.0" for "Apache License 2.0", or "" if nothing is close.
+func Suggest(name string) string {
+	return suggestions[suggestionKey(name)]
+}
+
+// textKey returns the name of the embedded text for a license identifier; the
+// "-only" and "-or-later" variants of a license share one text.
+func textKey(id string) string {
+	id = strings.TrimSuffix(id, "+")
+	id = strings.TrimSuffix(id, "-only")
+	return strings.TrimSuffix(id, "-or-later")
+}
+
+// HasText reports whether the full text of a license is available.
+func HasText(id string) bool {
+	canonical, ok := Lookup(strings.TrimSuffix(id, "+"))
+	if !ok {
+		return false
+	}
+	_, err := texts.ReadFile("texts/" + textKey(canonical) + ".txt")
+	return err == nil
+}
+
+// Common returns the identifiers whose full texts are available, for offering
+// as choices when creating a skill.
+func Common() []string {
+	return []string{
+		"MIT", "Apache-2.0", "BSD-2-Clause", "BSD-3-Clause", "ISC", "MPL-2.0",
+		"GPL-2.0-only", "GPL-3.0-only", "LGPL-2.1-only", "LGPL-3.0-only",
+		"Unlicense", "0BSD", "CC0-1.0",
+	}
+}
+
+// Text returns the full text of a license with the copyright holder and year
+// filled in where the license has a copyright line.
+func Text(id, holder string, year int) (string, error) {
+	canonical, ok := Lookup(strings.TrimSuffix(id, "+"))
+	if !ok {
+		return "", fmt.Errorf("unknown license '%s'", id)
+	}
+	content, err := texts.ReadFile("texts/" + textKey(canonical) + ".txt")
+	if err != nil {
+		return "", fmt.Errorf("the text of %s is not available", canonical)
+	}
+	tmpl, err := template.New(canonical).Parse(string(content))
+	if err != nil {
+		return "", err
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, struct {
+		Holder string
+		Year   int
+	}{holder, year})
+	return buffer.String(), err
+}
+
+// Render returns the contents of a license file for a license expression: the
+// full text of its license, or of each of its licenses in turn. Licenses whose
+// text is not available are referred to by their SPDX page.
+func Render(expression, holder string, year int) (string, error) {
+	expr, err := ParseExpression(expression)
+	if err != nil {
+		return "", err
+	}
+	ids := expr.Licenses()
+	if len(ids) == 1 && HasText(ids[0]) {
+		return Text(ids[0], holder, year)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "This project is licensed under %s.\n", expr)
+	for _, id := range ids {
+		fmt.Fprintf(&b, "\n%s\n%s\n\n", strings.Repeat("-", 72), id)
+		if IsCustom(id) {
+			fmt.Fprintf(&b, "[Add the text of %s here]\n", id)
+			continue
+		}
+		if !HasText(id) {
+			fmt.Fprintf(&b, "See https://spdx.org/licenses/%s.html\n", strings.TrimSuffix(id, "+"))
+			continue
+		}
+		text, err := Text(id, holder, year)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(text)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,547 @@
+// Package manifest edits skill.json documents in place. Unlike decoding into a
+// struct and encoding it again, edits only touch the bytes of the values they
+// change, so unknown fields, key order, indentation and formatting survive.
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// FileName is the name of the manifest file in a skill directory.
+const FileName = "skill.json"
+
+// Manifest is a JSON object document that can be read and edited by path.
+type Manifest struct {
+	content []byte
+	root    *node
+	indent  string // one level of indentation; empty for compact documents
+}
+
+// node is a parsed JSON value and its byte span in the document.
+type node struct {
+	kind       byte // '{', '[' or 0 for scalars
+	start, end int
+	members    []*member
+	elements   []*node
+}
+
+// member is a key and value of an object.
+type member struct {
+	key   string
+	start int // offset of the opening quote of the key
+	value *node
+}
+
+// Load reads and parses the manifest at path.
+func Load(path string) (*Manifest, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", FileName, err)
+	}
+	return Parse(content)
+}
+
+// Parse parses a manifest document, which must be a JSON object.
+func Parse(content []byte) (*Manifest, error) {
+	if !json.Valid(content) {
+		var v any
+		err := json.Unmarshal(content, &v)
+		return nil, fmt.Errorf("failed to parse %s: %w", FileName, err)
+	}
+	p := &parser{data: content}
+	p.skipSpace()
+	root := p.parseValue()
+	if root.kind != '{' {
+		return nil, fmt.Errorf("%s must contain a JSON object", FileName)
+	}
+	m := &Manifest{content: content, root: root}
+	m.indent = m.detectIndent()
+	return m, nil
+}
+
+// Bytes returns the current document.
+func (m *Manifest) Bytes() []byte {
+	return m.content
+}
+
+// Save writes the document to path.
+func (m *Manifest) Save(path string) error {
+	if err := os.WriteFile(path, m.content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", FileName, err)
+	}
+	return nil
+}
+
+// Decode unmarshals the whole document into v.
+func (m *Manifest) Decode(v any) error {
+	return json.Unmarshal(m.content, v)
+}
+
+// GetRaw returns the JSON text of the value at path, as it appears in the
+// document, and whether the path exists.
+func (m *Manifest) GetRaw(path string) (json.RawMessage, bool, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, false, err
+	}
+	n, _, _ := m.lookup(segments)
+	if n == nil {
+		return nil, false, nil
+	}
+	return json.RawMessage(m.content[n.start:n.end]), true, nil
+}
+
+// Get unmarshals the value at path into v and reports whether the path exists.
+func (m *Manifest) Get(path string, v any) (bool, error) {
+	raw, ok, err := m.GetRaw(path)
+	if err != nil || !ok {
+		return false, err
+	}
+	return true, json.Unmarshal(raw, v)
+}
+
+// GetString returns the string at path, or an empty string if the path does
+// not exist or is not a string.
+func (m *Manifest) GetString(path string) string {
+	var s string
+	if ok, err := m.Get(path, &s); !ok || err != nil {
+		return ""
+	}
+	return s
+}
+
+// Set sets the value at path, creating missing parent objects (or arrays, for
+// index 0). Existing keys
+// keep their position; new keys are appended to their object. An array index
+// equal to the array's length appends an element.
+func (m *Manifest) Set(path string, value any) error {
+	raw, err := marshal(value)
+	if err != nil {
+		return err
+	}
+	return m.SetRaw(path, raw)
+}
+
+// SetRaw is like Set but takes the value as JSON text.
+func (m *Manifest) SetRaw(path string, raw json.RawMessage) error {
+	if !json.Valid(raw) {
+		return fmt.Errorf("invalid JSON value for '%s'", path)
+	}
+	segments, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	if len(segments) == 0 {
+		return fmt.Errorf("cannot replace the whole manifest")
+	}
+
+	n, parent, depth := m.lookup(segments)
+	if n != nil {
+		return m.replace(n, raw)
+	}
+
+	// The path exists up to segments[depth-1]; create the rest below parent
+	missing := segments[depth:]
+	if parent.kind == '[' {
+		seg := missing[0]
+		if !seg.isIndex || seg.index != len(parent.elements) {
+			return fmt.Errorf("'%s' is out of range (the array has %d elements)", formatPath(segments[:depth+1]), len(parent.elements))
+		}
+		if len(missing) > 1 {
+			return fmt.Errorf("'%s' does not exist", formatPath(segments[:depth+1]))
+		}
+		return m.insertElement(parent, raw)
+	}
+	if parent.kind != '{' || missing[0].isIndex {
+		return fmt.Errorf("'%s' is not %s", formatPath(segments[:depth]), containerName(missing[0]))
+	}
+
+	// Wrap the value in an object for every missing key below the first, or
+	// in a new array for a missing index 0
+	for i := len(missing) - 1; i > 0; i-- {
+		if missing[i].isIndex {
+			if missing[i].index != 0 {
+				return fmt.Errorf("'%s' is out of range (the array has 0 elements)", formatPath(segments[:depth+i+1]))
+			}
+			raw = json.RawMessage(fmt.Sprintf("[%s]", raw))
+			continue
+		}
+		key, _ := marshal(missing[i].key)
+		raw = json.RawMessage(fmt.Sprintf("{%s:%s}", key, raw))
+	}
+	return m.insertMember(parent, missing[0].key, raw)
+}
+
+// Merge sets every field of value, which must encode as a JSON object, at the
+// top level of the document. Nested objects are merged key by key, so fields
+// the value does not mention are kept; arrays and scalars are replaced.
+func (m *Manifest) Merge(value any) error {
+	raw, err := marshal(value)
+	if err != nil {
+		return err
+	}
+	return m.mergeObject(nil, raw)
+}
+
+func (m *Manifest) mergeObject(prefix []segment, raw json.RawMessage) error {
+	fields, err := orderedMembers(raw)
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		path := append(append([]segment(nil), prefix...), segment{key: f.key})
+		existing, _, _ := m.lookup(path)
+		if existing != nil && existing.kind == '{' && bytes.HasPrefix(bytes.TrimSpace(f.raw), []byte("{")) {
+			if err := m.mergeObject(path, f.raw); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := m.SetRaw(formatPath(path), f.raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unset removes the value at path and reports whether it existed.
+func (m *Manifest) Unset(path string) (bool, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return false, err
+	}
+	if len(segments) == 0 {
+		return false, fmt.Errorf("cannot remove the whole manifest")
+	}
+	n, parent, _ := m.lookup(segments)
+	if n == nil {
+		return false, nil
+	}
+
+	// Spans of the entries in the parent container, in document order
+	var spans [][2]int
+	target := -1
+	if parent.kind == '{' {
+		for i, mem := range parent.members {
+			spans = append(spans, [2]int{mem.start, mem.value.end})
+			if mem.value == n {
+				target = i
+			}
+		}
+	} else {
+		for i, el := range parent.elements {
+			spans = append(spans, [2]int{el.start, el.end})
+			if el == n {
+				target = i
+			}
+		}
+	}
+
+	var start, end int
+	switch {
+	case len(spans) == 1:
+		// Leave an empty container
+		start, end = parent.start+1, parent.end-1
+	case target < len(spans)-1:
+		// Remove up to the start of the next entry, taking the comma with it
+		start, end = spans[target][0], spans[target+1][0]
+	default:
+		// Last entry: remove from the end of the previous one
+		start, end = spans[target-1][1], spans[target][1]
+	}
+	return true, m.splice(start, end, nil)
+}
+
+// lookup walks segments from the root. It returns the node at the path, or nil
+// and the deepest existing container with the number of segments it matched.
+func (m *Manifest) lookup(segments []segment) (n, parent *node, depth int) {
+	current := m.root
+	for i, seg := range segments {
+		var next *node
+		switch {
+		case current.kind == '{' && !seg.isIndex:
+			for _, mem := range current.members {
+				if mem.key == seg.key {
+					next = mem.value
+				}
+			}
+		case current.kind == '[' && seg.isIndex:
+			if seg.index < len(current.elements) {
+				next = current.elements[seg.index]
+			}
+		}
+		if next == nil {
+			return nil, current, i
+		}
+		parent, current = current, next
+	}
+	return current, parent, len(segments)
+}
+
+// replace overwrites a value, formatting it at the indentation of its line.
+func (m *Manifest) replace(n *node, raw json.RawMessage) error {
+	formatted, err := m.format(raw, m.lineIndent(n.start))
+	if err != nil {
+		return err
+	}
+	return m.splice(n.start, n.end, formatted)
+}
+
+// insertMember appends a key to an object.
+func (m *Manifest) insertMember(obj *node, key string, raw json.RawMessage) error {
+	quotedKey, _ := marshal(key)
+	colon := ":"
+	if m.indent != "" {
+		colon = ": "
+	}
+
+	if len(obj.members) == 0 {
+		return m.insertIntoEmpty(obj, func(indent string) ([]byte, error) {
+			value, err := m.format(raw, indent)
+			return append(append(quotedKey, colon...), value...), err
+		})
+	}
+
+	last := obj.members[len(obj.members)-1]
+	sep, prefix := m.separator(obj.start, obj.members[0].start)
+	value, err := m.formatEntry(raw, prefix)
+	if err != nil {
+		return err
+	}
+	entry := append(append(append([]byte(","+sep), quotedKey...), colon...), value...)
+	return m.splice(last.value.end, last.value.end, entry)
+}
+
+// insertElement appends an element to an array.
+func (m *Manifest) insertElement(arr *node, raw json.RawMessage) error {
+	if len(arr.elements) == 0 {
+		return m.insertIntoEmpty(arr, func(indent string) ([]byte, error) {
+			return m.format(raw, indent)
+		})
+	}
+	last := arr.elements[len(arr.elements)-1]
+	sep, prefix := m.separator(arr.start, arr.elements[0].start)
+	value, err := m.formatEntry(raw, prefix)
+	if err != nil {
+		return err
+	}
+	return m.splice(last.end, last.end, append([]byte(","+sep), value...))
+}
+
+// insertIntoEmpty fills an empty container with a single entry.
+func (m *Manifest) insertIntoEmpty(container *node, entry func(indent string) ([]byte, error)) error {
+	if m.indent == "" {
+		content, err := entry("")
+		if err != nil {
+			return err
+		}
+		return m.splice(container.start+1, container.end-1, content)
+	}
+	outer := m.lineIndent(container.start)
+	inner := outer + m.indent
+	content, err := entry(inner)
+	if err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	b.WriteString("\n" + inner)
+	b.Write(content)
+	b.WriteString("\n" + outer)
+	return m.splice(container.start+1, container.end-1, b.Bytes())
+}
+
+// separator returns the whitespace that separates entries of a container and
+// the prefix for continuation lines of a new entry. Entries of a container
+// written on a single line are kept on that line, so prefix is nil.
+func (m *Manifest) separator(containerStart, firstEntry int) (sep string, prefix *string) {
+	gap := m.content[containerStart+1 : firstEntry]
+	if i := bytes.LastIndexByte(gap, '\n'); i >= 0 {
+		indent := string(gap[i+1:])
+		return "\n" + indent, &indent
+	}
+	if m.indent != "" {
+		return " ", nil
+	}
+	return "", nil
+}
+
+// lineIndent returns the leading whitespace of the line containing offset.
+func (m *Manifest) lineIndent(offset int) string {
+	lineStart := bytes.LastIndexByte(m.content[:offset], '\n') + 1
+	end := lineStart
+	for end < len(m.content) && (m.content[end] == ' ' || m.content[end] == '\t') {
+		end++
+	}
+	return string(m.content[lineStart:end])
+}
+
+// detectIndent returns one level of the document's indentation, taken from
+// the first key of the root object.
+func (m *Manifest) detectIndent() string {
+	if len(m.root.members) == 0 {
+		return "  "
+	}
+	gap := m.content[m.root.start+1 : m.root.members[0].start]
+	i := bytes.LastIndexByte(gap, '\n')
+	if i < 0 {
+		return ""
+	}
+	return string(gap[i+1:]) // the root is at column zero
+}
+
+// format renders a value in the document's style, with continuation lines
+// indented by prefix.
+func (m *Manifest) format(raw json.RawMessage, prefix string) ([]byte, error) {
+	var out bytes.Buffer
+	if m.indent == "" {
+		if err := json.Compact(&out, raw); err != nil {
+			return nil, err
+		}
+		return out.Bytes(), nil
+	}
+	if err := json.Indent(&out, raw, prefix, m.indent); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// formatEntry formats a new container entry, compactly if prefix is nil.
+func (m *Manifest) formatEntry(raw json.RawMessage, prefix *string) ([]byte, error) {
+	if prefix == nil {
+		var out bytes.Buffer
+		err := json.Compact(&out, raw)
+		return out.Bytes(), err
+	}
+	return m.format(raw, *prefix)
+}
+
+// splice replaces content[start:end] and re-parses the document.
+func (m *Manifest) splice(start, end int, replacement []byte) error {
+	var b bytes.Buffer
+	b.Write(m.content[:start])
+	b.Write(replacement)
+	b.Write(m.content[end:])
+
+	indent := m.indent
+	updated, err := Parse(b.Bytes())
+	if err != nil {
+		return fmt.Errorf("internal error editing %s: %w", FileName, err)
+	}
+	*m = *updated
+	m.indent = indent
+	return nil
+}
+
+// marshal encodes a value as JSON without escaping HTML characters.
+func marshal(v any) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to encode value: %w", err)
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+type rawMember struct {
+	key string
+	raw json.RawMessage
+}
+
+// orderedMembers returns the members of a JSON object in document order.
+func orderedMembers(raw json.RawMessage) ([]rawMember, error) {
+	doc, err := Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	var members []rawMember
+	for _, mem := range doc.root.members {
+		members = append(members, rawMember{mem.key, json.RawMessage(doc.content[mem.value.start:mem.value.end])})
+	}
+	return members, nil
+}
+
+func containerName(seg segment) string {
+	if seg.isIndex {
+		return "an array"
+	}
+	return "an object"
+}
+
+// parser records the spans of values in a document already checked by json.Valid.
+type parser struct {
+	data []byte
+	pos  int
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) parseValue() *node {
+	n := &node{start: p.pos}
+	switch p.data[p.pos] {
+	case '{':
+		n.kind = '{'
+		p.pos++
+		p.skipSpace()
+		for p.data[p.pos] != '}' {
+			keyStart := p.pos
+			p.skipString()
+			var key string
+			json.Unmarshal(p.data[keyStart:p.pos], &key)
+			p.skipSpace()
+			p.pos++ // ':'
+			p.skipSpace()
+			n.members = append(n.members, &member{key: key, start: keyStart, value: p.parseValue()})
+			p.skipSpace()
+			if p.data[p.pos] == ',' {
+				p.pos++
+				p.skipSpace()
+			}
+		}
+		p.pos++
+	case '[':
+		n.kind = '['
+		p.pos++
+		p.skipSpace()
+		for p.data[p.pos] != ']' {
+			n.elements = append(n.elements, p.parseValue())
+			p.skipSpace()
+			if p.data[p.pos] == ',' {
+				p.pos++
+				p.skipSpace()
+			}
+		}
+		p.pos++
+	case '"':
+		p.skipString()
+	default:
+		for p.pos < len(p.data) && bytes.IndexByte([]byte(",]} \t\n\r"), p.data[p.pos]) < 0 {
+			p.pos++
+		}
+	}
+	n.end = p.pos
+	return n
+}
+
+func (p *parser) skipString() {
+	p.pos++ // opening quote
+	for p.data[p.pos] != '"' {
+		if p.data[p.pos] == '\\' {
+			p.pos++
+		}
+		p.pos++
+	}
+	p.pos++
+}
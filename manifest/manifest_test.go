@@ -0,0 +1,402 @@
+package manifest
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const (
+	twoSpaces  = "{\n  \"name\": \"a\",\n  \"version\": \"1.0.0\",\n  \"license\": \"MIT\"\n}\n"
+	fourSpaces = "{\n    \"name\": \"a\",\n    \"runtime\": {\n        \"type\": \"python\"\n    }\n}\n"
+	tabs       = "{\n\t\"name\": \"a\",\n\t\"dependencies\": {}\n}\n"
+	compact    = `{"name":"a","keywords":["x","y"],"dependencies":{}}`
+)
+
+// mustParse parses a test document, failing the test on error.
+func mustParse(t *testing.T, doc string) *Manifest {
+	t.Helper()
+	m, err := Parse([]byte(doc))
+	if err != nil {
+		t.Fatalf("Parse(%q) returned error: %v", doc, err)
+	}
+	return m
+}
+
+// checkResult compares an edited document with want and checks it is still
+// valid JSON.
+func checkResult(t *testing.T, m *Manifest, want string) {
+	t.Helper()
+	got := string(m.Bytes())
+	if got != want {
+		t.Errorf("got document:\n%s\nwant:\n%s", got, want)
+	}
+	if !json.Valid(m.Bytes()) {
+		t.Errorf("edited document is not valid JSON:\n%s", got)
+	}
+}
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		path  string
+		value any
+		want  string
+	}{
+		{
+			name:  "replace existing key",
+			doc:   twoSpaces,
+			path:  "version",
+			value: "2.0.0",
+			want:  "{\n  \"name\": \"a\",\n  \"version\": \"2.0.0\",\n  \"license\": \"MIT\"\n}\n",
+		},
+		{
+			name:  "replace with an object keeps the indentation",
+			doc:   fourSpaces,
+			path:  "runtime",
+			value: map[string]string{"type": "node", "version": ">=18"},
+			want:  "{\n    \"name\": \"a\",\n    \"runtime\": {\n        \"type\": \"node\",\n        \"version\": \">=18\"\n    }\n}\n",
+		},
+		{
+			name:  "replace nested key",
+			doc:   fourSpaces,
+			path:  "runtime.type",
+			value: "deno",
+			want:  "{\n    \"name\": \"a\",\n    \"runtime\": {\n        \"type\": \"deno\"\n    }\n}\n",
+		},
+		{
+			name:  "append key to non-empty object",
+			doc:   twoSpaces,
+			path:  "author",
+			value: "Jo",
+			want:  "{\n  \"name\": \"a\",\n  \"version\": \"1.0.0\",\n  \"license\": \"MIT\",\n  \"author\": \"Jo\"\n}\n",
+		},
+		{
+			name:  "append key to nested object",
+			doc:   fourSpaces,
+			path:  "runtime.version",
+			value: ">=3.9",
+			want:  "{\n    \"name\": \"a\",\n    \"runtime\": {\n        \"type\": \"python\",\n        \"version\": \">=3.9\"\n    }\n}\n",
+		},
+		{
+			name:  "insert into empty object with tabs",
+			doc:   tabs,
+			path:  "dependencies.python",
+			value: []string{"requests"},
+			want:  "{\n\t\"name\": \"a\",\n\t\"dependencies\": {\n\t\t\"python\": [\n\t\t\t\"requests\"\n\t\t]\n\t}\n}\n",
+		},
+		{
+			name:  "insert into empty root object",
+			doc:   "{}",
+			path:  "name",
+			value: "a",
+			want:  "{\n  \"name\": \"a\"\n}",
+		},
+		{
+			name:  "create nested path",
+			doc:   twoSpaces,
+			path:  "permissions.network.allowedHosts",
+			value: []string{"api.example.com"},
+			want:  "{\n  \"name\": \"a\",\n  \"version\": \"1.0.0\",\n  \"license\": \"MIT\",\n  \"permissions\": {\n    \"network\": {\n      \"allowedHosts\": [\n        \"api.example.com\"\n      ]\n    }\n  }\n}\n",
+		},
+		{
+			name:  "create nested path with array index",
+			doc:   tabs,
+			path:  "dependencies.skills[0]",
+			value: "acme/utils",
+			want:  "{\n\t\"name\": \"a\",\n\t\"dependencies\": {\n\t\t\"skills\": [\n\t\t\t\"acme/utils\"\n\t\t]\n\t}\n}\n",
+		},
+		{
+			name:  "compact document stays compact",
+			doc:   compact,
+			path:  "permissions.network.allowedHosts",
+			value: []string{"a.com"},
+			want:  `{"name":"a","keywords":["x","y"],"dependencies":{},"permissions":{"network":{"allowedHosts":["a.com"]}}}`,
+		},
+		{
+			name:  "compact empty object",
+			doc:   compact,
+			path:  "dependencies.skills",
+			value: []string{},
+			want:  `{"name":"a","keywords":["x","y"],"dependencies":{"skills":[]}}`,
+		},
+		{
+			name:  "replace array element",
+			doc:   compact,
+			path:  "keywords[1]",
+			value: "z",
+			want:  `{"name":"a","keywords":["x","z"],"dependencies":{}}`,
+		},
+		{
+			name:  "append array element",
+			doc:   compact,
+			path:  "keywords[2]",
+			value: "z",
+			want:  `{"name":"a","keywords":["x","y","z"],"dependencies":{}}`,
+		},
+		{
+			name:  "key that needs escaping",
+			doc:   twoSpaces,
+			path:  `["a.b\"c"]`,
+			value: 1,
+			want:  "{\n  \"name\": \"a\",\n  \"version\": \"1.0.0\",\n  \"license\": \"MIT\",\n  \"a.b\\\"c\": 1\n}\n",
+		},
+		{
+			name:  "value that needs escaping",
+			doc:   twoSpaces,
+			path:  "description",
+			value: "Says \"hi\" <b>\n",
+			want:  "{\n  \"name\": \"a\",\n  \"version\": \"1.0.0\",\n  \"license\": \"MIT\",\n  \"description\": \"Says \\\"hi\\\" <b>\\n\"\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mustParse(t, tt.doc)
+			if err := m.Set(tt.path, tt.value); err != nil {
+				t.Fatalf("Set(%q) returned error: %v", tt.path, err)
+			}
+			checkResult(t, m, tt.want)
+		})
+	}
+}
+
+func TestSetErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"whole document", ""},
+		{"index past the end", "keywords[3]"},
+		{"key below an array", "keywords.name"},
+		{"key below a string", "name.first"},
+		{"index below an object", "dependencies[0]"},
+		{"invalid path", "keywords[x]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mustParse(t, compact)
+			if err := m.Set(tt.path, "v"); err == nil {
+				t.Errorf("Set(%q) returned no error", tt.path)
+			}
+			if string(m.Bytes()) != compact {
+				t.Errorf("failed Set(%q) changed the document to %s", tt.path, m.Bytes())
+			}
+		})
+	}
+
+	if err := mustParse(t, compact).SetRaw("name", []byte(`{"broken"`)); err == nil {
+		t.Error("SetRaw with invalid JSON returned no error")
+	}
+}
+
+func TestUnset(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		path string
+		want string
+	}{
+		{
+			name: "first member",
+			doc:  twoSpaces,
+			path: "name",
+			want: "{\n  \"version\": \"1.0.0\",\n  \"license\": \"MIT\"\n}\n",
+		},
+		{
+			name: "middle member",
+			doc:  twoSpaces,
+			path: "version",
+			want: "{\n  \"name\": \"a\",\n  \"license\": \"MIT\"\n}\n",
+		},
+		{
+			name: "last member",
+			doc:  twoSpaces,
+			path: "license",
+			want: "{\n  \"name\": \"a\",\n  \"version\": \"1.0.0\"\n}\n",
+		},
+		{
+			name: "only member",
+			doc:  fourSpaces,
+			path: "runtime.type",
+			want: "{\n    \"name\": \"a\",\n    \"runtime\": {}\n}\n",
+		},
+		{
+			name: "nested object",
+			doc:  fourSpaces,
+			path: "runtime",
+			want: "{\n    \"name\": \"a\"\n}\n",
+		},
+		{
+			name: "compact first member",
+			doc:  compact,
+			path: "name",
+			want: `{"keywords":["x","y"],"dependencies":{}}`,
+		},
+		{
+			name: "compact middle member",
+			doc:  compact,
+			path: "keywords",
+			want: `{"name":"a","dependencies":{}}`,
+		},
+		{
+			name: "compact last member",
+			doc:  compact,
+			path: "dependencies",
+			want: `{"name":"a","keywords":["x","y"]}`,
+		},
+		{
+			name: "first array element",
+			doc:  compact,
+			path: "keywords[0]",
+			want: `{"name":"a","keywords":["y"],"dependencies":{}}`,
+		},
+		{
+			name: "last array element",
+			doc:  compact,
+			path: "keywords[1]",
+			want: `{"name":"a","keywords":["x"],"dependencies":{}}`,
+		},
+		{
+			name: "tab-indented member",
+			doc:  tabs,
+			path: "dependencies",
+			want: "{\n\t\"name\": \"a\"\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mustParse(t, tt.doc)
+			ok, err := m.Unset(tt.path)
+			if err != nil || !ok {
+				t.Fatalf("Unset(%q) = %v, %v; want true, nil", tt.path, ok, err)
+			}
+			checkResult(t, m, tt.want)
+		})
+	}
+
+	m := mustParse(t, twoSpaces)
+	if ok, err := m.Unset("author"); ok || err != nil {
+		t.Errorf("Unset of a missing key = %v, %v; want false, nil", ok, err)
+	}
+	checkResult(t, m, twoSpaces)
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		value any
+		want  string
+	}{
+		{
+			name:  "nested objects are merged",
+			doc:   fourSpaces,
+			value: map[string]any{"runtime": map[string]any{"version": ">=3.9"}},
+			want:  "{\n    \"name\": \"a\",\n    \"runtime\": {\n        \"type\": \"python\",\n        \"version\": \">=3.9\"\n    }\n}\n",
+		},
+		{
+			name: "scalars and arrays are replaced, new keys appended in order",
+			doc:  compact,
+			value: struct {
+				Keywords []string `json:"keywords"`
+				Version  string   `json:"version"`
+				Author   string   `json:"author"`
+			}{[]string{"z"}, "1.0.0", "Jo"},
+			want: `{"name":"a","keywords":["z"],"dependencies":{},"version":"1.0.0","author":"Jo"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mustParse(t, tt.doc)
+			if err := m.Merge(tt.value); err != nil {
+				t.Fatalf("Merge returned error: %v", err)
+			}
+			checkResult(t, m, tt.want)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	m := mustParse(t, "{\"a.b\": {\"c\": [1, {\"d\": \"e\"}]}, \"name\": \"x\"}")
+	tests := []struct {
+		path string
+		want string
+		ok   bool
+	}{
+		{`["a.b"].c[1].d`, `"e"`, true},
+		{`$["a.b"].c[0]`, `1`, true},
+		{"name", `"x"`, true},
+		{"missing", "", false},
+		{`["a.b"].c[5]`, "", false},
+	}
+	for _, tt := range tests {
+		raw, ok, err := m.GetRaw(tt.path)
+		if err != nil {
+			t.Errorf("GetRaw(%q) returned error: %v", tt.path, err)
+			continue
+		}
+		if ok != tt.ok || string(raw) != tt.want {
+			t.Errorf("GetRaw(%q) = %s, %v; want %s, %v", tt.path, raw, ok, tt.want, tt.ok)
+		}
+	}
+	if got := m.GetString("name"); got != "x" {
+		t.Errorf("GetString(name) = %q, want x", got)
+	}
+}
+
+// TestRoundTrip applies a series of edits and checks that the document stays
+// valid JSON and decodes to the expected values.
+func TestRoundTrip(t *testing.T) {
+	for _, doc := range []string{twoSpaces, fourSpaces, tabs, compact, "{}"} {
+		m := mustParse(t, doc)
+		edits := []func() error{
+			func() error { return m.Set("version", "1.2.3") },
+			func() error { return m.Set("permissions.filesystem.paths[0]", "out/") },
+			func() error { return m.Set("permissions.filesystem.access", "write") },
+			func() error { return m.Set(`["we\"ird key"]`, true) },
+			func() error { _, err := m.Unset("name"); return err },
+			func() error { return m.Merge(map[string]any{"permissions": map[string]any{"network": nil}}) },
+		}
+		for i, edit := range edits {
+			if err := edit(); err != nil {
+				t.Fatalf("edit %d of %q returned error: %v", i, doc, err)
+			}
+			if !json.Valid(m.Bytes()) {
+				t.Fatalf("edit %d of %q left invalid JSON:\n%s", i, doc, m.Bytes())
+			}
+		}
+
+		var data struct {
+			Name        *string `json:"name"`
+			Version     string  `json:"version"`
+			Weird       bool    `json:"we\"ird key"`
+			Permissions struct {
+				Filesystem struct {
+					Access string   `json:"access"`
+					Paths  []string `json:"paths"`
+				} `json:"filesystem"`
+				Network any `json:"network"`
+			} `json:"permissions"`
+		}
+		if err := m.Decode(&data); err != nil {
+			t.Fatalf("Decode of %q returned error: %v", doc, err)
+		}
+		fs := data.Permissions.Filesystem
+		if data.Name != nil || data.Version != "1.2.3" || fs.Access != "write" || len(fs.Paths) != 1 || fs.Paths[0] != "out/" || data.Permissions.Network != nil {
+			t.Errorf("edited %q decodes to %+v", doc, data)
+		}
+
+		reparsed := mustParse(t, string(m.Bytes()))
+		if string(reparsed.Bytes()) != string(m.Bytes()) {
+			t.Errorf("reparsing changed the document")
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, doc := range []string{"", "[]", `"name"`, `{"name": }`, `{"a": 1,}`} {
+		if _, err := Parse([]byte(doc)); err == nil {
+			t.Errorf("Parse(%q) returned no error", doc)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// segment is one step of a path: an object key or an array index.
+type segment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+func (s segment) String() string {
+	if s.isIndex {
+		return fmt.Sprintf("[%d]", s.index)
+	}
+	return s.key
+}
+
+// parsePath parses a path such as "runtime.version", "keywords[0]",
+// "permissions.network.allowedHosts[1]" or `["key.with.dots"]`. A leading "$"
+// is accepted. An empty path refers to the whole document.
+func parsePath(path string) ([]segment, error) {
+	p := strings.TrimPrefix(strings.TrimSpace(path), "$")
+	p = strings.TrimPrefix(p, ".")
+
+	var segments []segment
+	for i := 0; i < len(p); {
+		switch p[i] {
+		case '.':
+			if i+1 >= len(p) || p[i+1] == '.' || p[i+1] == '[' {
+				return nil, fmt.Errorf("invalid path '%s': empty key", path)
+			}
+			i++
+		case '[':
+			end := strings.IndexByte(p[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path '%s': missing ']'", path)
+			}
+			inner := p[i+1 : i+end]
+			if strings.HasPrefix(inner, `"`) {
+				// A quoted key may itself contain ']'
+				end = closingQuotedBracket(p, i+1)
+				if end < 0 {
+					return nil, fmt.Errorf("invalid path '%s': unterminated quoted key", path)
+				}
+				var key string
+				if err := json.Unmarshal([]byte(p[i+1:end]), &key); err != nil {
+					return nil, fmt.Errorf("invalid path '%s': %w", path, err)
+				}
+				segments = append(segments, segment{key: key})
+				i = end + 1
+				continue
+			}
+			n, err := strconv.Atoi(inner)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid path '%s': '%s' is not an array index", path, inner)
+			}
+			segments = append(segments, segment{index: n, isIndex: true})
+			i += end + 1
+		default:
+			end := strings.IndexAny(p[i:], ".[")
+			if end < 0 {
+				end = len(p) - i
+			}
+			segments = append(segments, segment{key: p[i : i+end]})
+			i += end
+		}
+	}
+	return segments, nil
+}
+
+// closingQuotedBracket returns the index of the ']' that follows the JSON
+// string starting at p[start], or -1.
+func closingQuotedBracket(p string, start int) int {
+	for i := start + 1; i < len(p); i++ {
+		switch p[i] {
+		case '\\':
+			i++
+		case '"':
+			if i+1 < len(p) && p[i+1] == ']' {
+				return i + 1
+			}
+			return -1
+		}
+	}
+	return -1
+}
+
+// formatPath renders segments back into path syntax for error messages.
+func formatPath(segments []segment) string {
+	var b strings.Builder
+	for i, s := range segments {
+		switch {
+		case s.isIndex:
+			b.WriteString(s.String())
+		case strings.ContainsAny(s.key, ".[]") || s.key == "":
+			quoted, _ := json.Marshal(s.key)
+			fmt.Fprintf(&b, "[%s]", quoted)
+		default:
+			if i > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(s.key)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,234 @@
+// Package markdown reads the parts of a Markdown document that skill checks
+// care about: headings, links, images and inline code, outside of code
+// blocks. It is not a full CommonMark parser, but handles the constructs
+// found in READMEs and agent instructions.
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Heading is an ATX ("## Title") or setext heading.
+type Heading struct {
+	Level int
+	Text  string
+	Line  int
+}
+
+// Link is a link or image target in the document, from Markdown syntax,
+// a reference definition or an HTML <a>/<img> tag.
+type Link struct {
+	Target string
+	Image  bool
+	Line   int
+}
+
+// Code is an inline code span.
+type Code struct {
+	Text string
+	Line int
+}
+
+// Document is a parsed Markdown document.
+type Document struct {
+	Headings []Heading
+	Links    []Link
+	Code     []Code
+	// Body is the document without its front matter, which starts on line
+	// BodyLine.
+	Body     string
+	BodyLine int
+}
+
+var (
+	fencePattern      = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	atxPattern        = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	setextPattern     = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	referencePattern  = regexp.MustCompile(`^ {0,3}\[[^\]]+\]:[ \t]*(<[^>]*>|\S+)`)
+	inlineLinkPattern = regexp.MustCompile(`(!?)\[(?:[^\[\]]|\[[^\[\]]*\])*\]\(\s*(<[^>]*>|(?:[^\s()]|\([^\s()]*\))+)(?:\s+(?:"[^"]*"|'[^']*'|\([^)]*\)))?\s*\)`)
+	htmlLinkPattern   = regexp.MustCompile(`(?i)<(a|img)\b[^>]*?\s(?:href|src)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	listItemPattern   = regexp.MustCompile(`^ {0,3}(?:[-*+]|\d+[.)])[ \t]`)
+	escapedBracket    = regexp.MustCompile(`\\[\\\[\]]`)
+)
+
+// Parse reads a Markdown document. A YAML front matter block at the start,
+// as used by SKILL.md, is skipped; line numbers still count it.
+func Parse(content string) *Document {
+	doc := &Document{}
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	start := 0
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		for i := 1; i < len(lines); i++ {
+			if t := strings.TrimSpace(lines[i]); t == "---" || t == "..." {
+				start = i + 1
+				break
+			}
+		}
+	}
+	doc.Body = strings.Join(lines[start:], "\n")
+	doc.BodyLine = start + 1
+
+	fence := ""
+	previousProse := ""
+	for i := 0; i < len(lines); i++ {
+		if i < start {
+			continue
+		}
+		line := lines[i]
+		number := i + 1
+
+		if fence != "" {
+			if match := fencePattern.FindStringSubmatch(line); match != nil && match[1][0] == fence[0] && len(match[1]) >= len(fence) && strings.TrimSpace(line[strings.Index(line, match[1])+len(match[1]):]) == "" {
+				fence = ""
+			}
+			continue
+		}
+		if match := fencePattern.FindStringSubmatch(line); match != nil {
+			fence = match[1]
+			previousProse = ""
+			continue
+		}
+		// Indented code, unless it continues a list item or paragraph
+		if (strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")) && previousProse == "" {
+			continue
+		}
+
+		if match := atxPattern.FindStringSubmatch(line); match != nil {
+			doc.Headings = append(doc.Headings, Heading{Level: len(match[1]), Text: strings.TrimSpace(match[2]), Line: number})
+			previousProse = ""
+			continue
+		}
+		if match := setextPattern.FindStringSubmatch(line); match != nil && previousProse != "" && !listItemPattern.MatchString(previousProse) {
+			level := 1
+			if match[1][0] == '-' {
+				level = 2
+			}
+			doc.Headings = append(doc.Headings, Heading{Level: level, Text: strings.TrimSpace(previousProse), Line: number - 1})
+			previousProse = ""
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			previousProse = ""
+		} else {
+			previousProse = line
+		}
+
+		if match := referencePattern.FindStringSubmatch(line); match != nil {
+			doc.Links = append(doc.Links, Link{Target: strings.Trim(match[1], "<>"), Line: number})
+			continue
+		}
+		// Links inside code spans and escaped brackets are not links
+		prose := line
+		for _, span := range codeSpans(line) {
+			doc.Code = append(doc.Code, Code{Text: strings.TrimSpace(line[span[0]+span[2] : span[1]-span[2]]), Line: number})
+			prose = prose[:span[0]] + strings.Repeat(" ", span[1]-span[0]) + prose[span[1]:]
+		}
+		prose = escapedBracket.ReplaceAllString(prose, "  ")
+		for _, match := range inlineLinkPattern.FindAllStringSubmatch(prose, -1) {
+			doc.Links = append(doc.Links, Link{Target: strings.Trim(match[2], "<>"), Image: match[1] == "!", Line: number})
+		}
+		for _, match := range htmlLinkPattern.FindAllStringSubmatch(prose, -1) {
+			doc.Links = append(doc.Links, Link{Target: match[2] + match[3], Image: strings.EqualFold(match[1], "img"), Line: number})
+		}
+	}
+	return doc
+}
+
+// Anchors returns the fragment identifiers of the document's headings, as
+// generated by GitHub and the Skilzy registry: repeated headings get "-1",
+// "-2" and so on.
+func (d *Document) Anchors() map[string]bool {
+	anchors := map[string]bool{}
+	counts := map[string]int{}
+	for _, h := range d.Headings {
+		slug := Slug(h.Text)
+		anchor := slug
+		if n := counts[slug]; n > 0 {
+			anchor = slug + "-" + strconv.Itoa(n)
+		}
+		counts[slug]++
+		anchors[anchor] = true
+	}
+	return anchors
+}
+
+// codeSpans finds the inline code spans in a line. Each span is returned as
+// its start and end offsets and the length of its backtick delimiters.
+func codeSpans(line string) [][3]int {
+	var spans [][3]int
+	for i := 0; i < len(line); {
+		if line[i] != '`' {
+			i++
+			continue
+		}
+		open := backtickRun(line, i)
+		// The span ends at the next run of exactly as many backticks
+		end := -1
+		for j := i + open; j < len(line); {
+			if line[j] != '`' {
+				j++
+				continue
+			}
+			run := backtickRun(line, j)
+			if run == open {
+				end = j + run
+				break
+			}
+			j += run
+		}
+		if end < 0 {
+			i += open
+			continue
+		}
+		spans = append(spans, [3]int{i, end, open})
+		i = end
+	}
+	return spans
+}
+
+func backtickRun(line string, i int) int {
+	n := 0
+	for i+n < len(line) && line[i+n] == '`' {
+		n++
+	}
+	return n
+}
+
+// inlineMarkup matches emphasis, code and link syntax to drop from heading
+// text before computing its anchor. Underscores are kept, as in snake_case.
+var inlineMarkup = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)|[*` + "`" + `~]`)
+
+// Slug returns the anchor of a heading: lower case, with punctuation removed
+// and spaces replaced by hyphens.
+func Slug(text string) string {
+	text = inlineMarkup.ReplaceAllString(text, "$1")
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+// EstimateTokens approximates the number of tokens a language model's
+// tokenizer produces for text. English prose averages about four characters
+// per token; words are counted too so that text of many short words is not
+// underestimated.
+func EstimateTokens(text string) int {
+	chars := len([]rune(text))
+	words := len(strings.Fields(text))
+	byChars := (chars + 3) / 4
+	byWords := words * 4 / 3
+	if byWords > byChars {
+		return byWords
+	}
+	return byChars
+}
@@ -8,6 +8,11 @@ import (
     "os"
     "path/filepath"
     "strings"
+    "time"
+
+    "github.com/skilzy/skilzy-cli/icon"
+    "github.com/skilzy/skilzy-cli/license"
+    "github.com/skilzy/skilzy-cli/manifest"
 )
 
 type SkillData struct {
@@ -33,21 +38,108 @@ type Repository struct {
 
 type Runtime struct {
     Type    string `json:"type"`
-    Version string `json:"version"`
+    Version string `json:"version,omitempty"`
+}
+
+// Runtime types. RuntimeNone is for instruction-only skills without scripts.
+const (
+    RuntimePython = "python"
+    RuntimeNode   = "node"
+    RuntimeDeno   = "deno"
+    RuntimeShell  = "shell"
+    RuntimeNone   = "none"
+)
+
+// RuntimeTypes lists the supported runtime types.
+var RuntimeTypes = []string{RuntimePython, RuntimeNode, RuntimeDeno, RuntimeShell, RuntimeNone}
+
+// DefaultRuntimeVersion returns the version constraint new skills use for a
+// runtime type, or "" if the runtime is not versioned by default.
+func DefaultRuntimeVersion(runtimeType string) string {
+    switch runtimeType {
+    case RuntimePython:
+        return ">=3.9"
+    case RuntimeNode:
+        return ">=18"
+    case RuntimeDeno:
+        return ">=2"
+    }
+    return ""
+}
+
+// runtimeNames are the display names of the runtime types.
+var runtimeNames = map[string]string{
+    RuntimePython: "Python",
+    RuntimeNode:   "Node.js",
+    RuntimeDeno:   "Deno",
+    RuntimeShell:  "Bash",
+}
+
+// Requirement describes the runtime for humans, e.g. "Python >=3.9". It is
+// empty for instruction-only skills.
+func (r Runtime) Requirement() string {
+    name, ok := runtimeNames[r.Type]
+    if !ok {
+        return ""
+    }
+    if r.Version == "" {
+        return name
+    }
+    return name + " " + r.Version
 }
 
 type Dependencies struct {
     System []string `json:"system,omitempty"`
     Python []string `json:"python,omitempty"`
+    Npm    []string `json:"npm,omitempty"`
     Skills []string `json:"skills,omitempty"`
 }
 
+// Permissions declares the resources a skill needs at runtime.
 type Permissions struct {
-    // Empty for now, can be expanded later
+    Network    *NetworkPermission    `json:"network,omitempty"`
+    Filesystem *FilesystemPermission `json:"filesystem,omitempty"`
+}
+
+// NetworkPermission lists the hosts a skill contacts. Hosts are hostnames,
+// IP addresses or "*.example.com" wildcards, optionally with a port.
+type NetworkPermission struct {
+    AllowedHosts []string `json:"allowedHosts"`
+    Description  string   `json:"description"`
 }
 
-// Create generates the directory and skill.json for a new skill from scratch.
-func Create(data SkillData) error {
+// Filesystem access levels.
+const (
+    FilesystemAccessRead      = "read"
+    FilesystemAccessWrite     = "write"
+    FilesystemAccessReadWrite = "readWrite"
+    FilesystemAccessNone      = "none"
+)
+
+// FilesystemPermission declares how a skill accesses files. Paths are
+// relative to the skill directory.
+type FilesystemPermission struct {
+    Access      string   `json:"access"`
+    Paths       []string `json:"paths,omitempty"`
+    Description string   `json:"description"`
+}
+
+// LicenseText returns the contents of a license file for the skill: the full
+// text of its license, with the author as copyright holder. If the license is
+// not a valid SPDX expression, a one-line notice is returned instead.
+func LicenseText(data SkillData) string {
+    text, err := license.Render(data.License, data.Author, time.Now().Year())
+    if err != nil {
+        return fmt.Sprintf("This project is licensed under the %s license.\n", data.License)
+    }
+    return text
+}
+
+// Create generates the directory for a new skill from a template, rendering
+// the template's files with data and the answers to its prompts, and writes
+// skill.json. A license file and a generated icon are added if the template
+// has none.
+func Create(data SkillData, tmpl *Template, answers map[string]interface{}) error {
     skillDir := data.Name
     fmt.Printf("🚀 Initializing skill: %s...\n", skillDir)
 
@@ -59,57 +151,65 @@ func Create(data SkillData) error {
     }
     fmt.Printf("✅ Created skill directory: ./%s\n", skillDir)
 
-    if err := WriteManifest(skillDir, data); err != nil {
+    created, err := tmpl.Render(skillDir, data, answers)
+    if err != nil {
         return err
     }
-
-    // Create README.md (entrypoint - shown on registry)
-    readmePath := filepath.Join(skillDir, data.Entrypoint)
-    skillTitle := strings.ToTitle(strings.ReplaceAll(data.Name, "-", " "))
-    readmeContent := fmt.Sprintf("# %s\n\n## Overview\n\n%s\n\n## Features\n\n- Feature 1: [Describe key capability]\n- Feature 2: [Describe another capability]\n- Feature 3: [Describe additional functionality]\n\n## Usage\n\n[Provide examples of how to use this skill]\n\n## Requirements\n\n- Python >= 3.9\n- [List any other dependencies]\n\n## Installation\n\nThis skill is available through the Skilzy Registry. Install it using:\n\n```bash\nskilzy install your-username/%s\n```\n\n## License\n\n%s\n\n## Author\n\n%s\n\n---\n\n**Note:** This README is displayed on the Skilzy registry details page. The actual AI agent instructions are in SKILL.md.\n", 
-        skillTitle, data.Description, data.Name, data.License, data.Author)
-    if err := os.WriteFile(readmePath, []byte(readmeContent), 0644); err != nil {
-        return fmt.Errorf("failed to write README.md: %w", err)
-    }
-    fmt.Printf("✅ Created %s\n", readmePath)
-
-    // Create SKILL.md (AI agent instructions)
-    skillMDPath := filepath.Join(skillDir, "SKILL.md")
-    skillMDContent := fmt.Sprintf("---\nname: %s\ndescription: %s\n---\n\n# %s\n\n## Overview\n\nThis skill is designed to...\n\n## When to Use This Skill\n\nThis skill should be used when...\n\n## Instructions\n\nRun 'skilzy validate' when ready\n\n## Resources\n\n[Reference any scripts, references, or assets included with this skill]\n", 
-        data.Name, data.Description, skillTitle)
-    if err := os.WriteFile(skillMDPath, []byte(skillMDContent), 0644); err != nil {
-        return fmt.Errorf("failed to write SKILL.md: %w", err)
+    for _, path := range created {
+        if strings.HasSuffix(path, "/") {
+            fmt.Printf("✅ Created directory: %s/\n", filepath.Join(skillDir, path))
+        } else {
+            fmt.Printf("✅ Created %s\n", filepath.Join(skillDir, path))
+        }
     }
-    fmt.Printf("✅ Created SKILL.md\n")
 
-    licensePath := filepath.Join(skillDir, data.LicenseFile)
-    if err := os.WriteFile(licensePath, []byte(fmt.Sprintf("This project is licensed under the %s license.", data.License)), 0644); err != nil {
-        return fmt.Errorf("failed to write LICENSE: %w", err)
+    // A template may ship its own skill.json, which data is merged into
+    if err := WriteManifest(skillDir, data); err != nil {
+        return err
     }
-    fmt.Printf("✅ Created %s\n", licensePath)
 
-    subDirs := []string{"assets", "scripts", "reference"}
-    for _, dir := range subDirs {
-        fullPath := filepath.Join(skillDir, dir)
-        if err := os.Mkdir(fullPath, 0755); err != nil {
-            return fmt.Errorf("failed to create directory %s: %w", dir, err)
+    licensePath := filepath.Join(skillDir, data.LicenseFile)
+    if _, err := os.Stat(licensePath); os.IsNotExist(err) {
+        if err := os.WriteFile(licensePath, []byte(LicenseText(data)), 0644); err != nil {
+            return fmt.Errorf("failed to write LICENSE: %w", err)
         }
-        fmt.Printf("✅ Created directory: %s/\n", fullPath)
+        fmt.Printf("✅ Created %s\n", licensePath)
     }
 
     iconPath := filepath.Join(skillDir, data.Icon)
-    iconContent := "<?xml version=\"1.0\" encoding=\"UTF-8\"?><svg id=\"a\" xmlns=\"http://www.w3.org/2000/svg\" width=\"12.52mm\" height=\"12.09mm\" viewBox=\"0 0 35.48 34.27\"><rect x=\"0\" y=\"0\" width=\"35.48\" height=\"34.27\" style=\"fill:#755541;\"/><path d=\"M6.13,26.42v-2.85h9.88v2.85H6.13Z\" style=\"fill:#fff;\"/><path d=\"M24.09,23.93c-1.56,0-2.82-.25-3.78-.76-.96-.51-1.69-1.12-2.19-1.85-.5-.73-.82-1.44-.96-2.13l3.52-1.15c.16.63.51,1.19,1.03,1.69s1.32.74,2.37.74c.95,0,1.6-.15,1.96-.44.35-.29.53-.61.53-.96,0-.4-.21-.76-.64-1.06-.42-.3-1.28-.51-2.55-.61-1.68-.14-3.03-.59-4.04-1.34-1.01-.75-1.52-1.83-1.52-3.25v-.18c0-.99.27-1.83.82-2.52.55-.69,1.26-1.21,2.13-1.56s1.79-.53,2.76-.53c1.28,0,2.33.2,3.16.59.83.39,1.48.89,1.96,1.47.48.59.81,1.17,1.02,1.76l-3.46,1.4c-.18-.55-.5-.98-.94-1.29-.45-.31-1.02-.47-1.73-.47-.63,0-1.11.12-1.44.35-.33.23-.5.52-.5.87,0,.49.25.83.76,1.03.51.2,1.43.38,2.76.55,1.54.16,2.79.62,3.77,1.38.97.76,1.46,1.82,1.46,3.17v.18c0,1.48-.55,2.67-1.64,3.57-1.09.9-2.63,1.35-4.62,1.35Z\" style=\"fill:#fff;\"/></svg>"
-    if err := os.WriteFile(iconPath, []byte(iconContent), 0644); err != nil {
-        return fmt.Errorf("failed to write icon.svg: %w", err)
+    if _, err := os.Stat(iconPath); os.IsNotExist(err) {
+        if err := os.MkdirAll(filepath.Dir(iconPath), 0755); err != nil {
+            return fmt.Errorf("failed to create directory for the icon: %w", err)
+        }
+        if err := os.WriteFile(iconPath, []byte(icon.Generate(data.Name)), 0644); err != nil {
+            return fmt.Errorf("failed to write icon.svg: %w", err)
+        }
+        fmt.Printf("✅ Created %s\n", iconPath)
     }
-    fmt.Printf("✅ Created %s\n", iconPath)
 
     return nil
 }
 
-// WriteManifest is a helper to only write the skill.json file.
+// WriteManifest is a helper to only write the skill.json file. If skillDir
+// already has a skill.json, the fields in data are merged into it so that keys
+// SkillData does not model, key order and formatting are preserved.
 func WriteManifest(skillDir string, data SkillData) error {
-	manifestPath := filepath.Join(skillDir, "skill.json")
+	manifestPath := filepath.Join(skillDir, manifest.FileName)
+	if _, err := os.Stat(manifestPath); err == nil {
+		existing, err := manifest.Load(manifestPath)
+		if err != nil {
+			return err
+		}
+		if err := existing.Merge(data); err != nil {
+			return fmt.Errorf("failed to update skill.json: %w", err)
+		}
+		if err := existing.Save(manifestPath); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Updated %s\n", manifestPath)
+		return nil
+	}
+
 	var buffer bytes.Buffer
 	encoder := json.NewEncoder(&buffer)
 	encoder.SetIndent("", "  ")
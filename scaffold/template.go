@@ -0,0 +1,502 @@
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/skilzy/skilzy-cli/markdown"
+	"github.com/skilzy/skilzy-cli/utils"
+)
+
+//go:embed all:templates
+var builtinTemplates embed.FS
+
+// DefaultTemplate is the template used when none is given.
+const DefaultTemplate = "minimal"
+
+// TemplateConfigFile describes a template; it is not copied into new skills.
+const TemplateConfigFile = "template.json"
+
+// Prompt types.
+const (
+	PromptInput   = "input"
+	PromptConfirm = "confirm"
+	PromptSelect  = "select"
+	PromptList    = "list" // comma-separated input, answered as a []string
+)
+
+// Template is a directory of files from which new skills are created. Files
+// ending in ".tmpl" are rendered with text/template (and lose the suffix),
+// other files are copied as they are. Paths may contain template actions too.
+type Template struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Runtime     *Runtime `json:"runtime,omitempty"` // default runtime for skills created from the template
+	Directories []string `json:"directories,omitempty"`
+	Prompts     []Prompt `json:"prompts,omitempty"`
+
+	files   fs.FS
+	tempDir string // checkout of a remote template, removed by Close
+}
+
+// Prompt is a question a template asks when a skill is created. Its answer is
+// available to the template's files as .Answers.<Name> and, if Field is set,
+// is also stored in that field of the manifest.
+type Prompt struct {
+	Name     string      `json:"name"`
+	Message  string      `json:"message"`
+	Type     string      `json:"type,omitempty"` // defaults to "input"
+	Help     string      `json:"help,omitempty"`
+	Default  interface{} `json:"default,omitempty"`
+	Options  []string    `json:"options,omitempty"` // for "select"
+	Required bool        `json:"required,omitempty"`
+	Field    string      `json:"field,omitempty"` // see PromptFields
+}
+
+// PromptFields are the manifest fields a prompt can set.
+var PromptFields = []string{
+	"description", "author", "license", "keywords", "runtime.type", "runtime.version",
+	"dependencies.system", "dependencies.python", "dependencies.npm",
+}
+
+var promptNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// BuiltinTemplates returns the templates shipped with the CLI, sorted by name.
+func BuiltinTemplates() ([]*Template, error) {
+	entries, err := fs.ReadDir(builtinTemplates, "templates")
+	if err != nil {
+		return nil, err
+	}
+	var templates []*Template
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		t, err := LoadBuiltinTemplate(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// LoadBuiltinTemplate loads a template shipped with the CLI.
+func LoadBuiltinTemplate(name string) (*Template, error) {
+	if !fs.ValidPath(name) || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("unknown template '%s'", name)
+	}
+	files, err := fs.Sub(builtinTemplates, path.Join("templates", name))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fs.Stat(files, TemplateConfigFile); err != nil {
+		return nil, fmt.Errorf("unknown template '%s'", name)
+	}
+	return loadTemplate(files, name)
+}
+
+// LoadTemplate loads a template from source, which is the name of a built-in
+// template, a local directory, or a git URL optionally followed by "#<ref>".
+// Call Close when done with the template.
+func LoadTemplate(source string) (*Template, error) {
+	if isGitURL(source) {
+		url, ref, _ := strings.Cut(source, "#")
+		dir, err := os.MkdirTemp("", "skilzy-template-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		if err := utils.GitClone(url, ref, dir); err != nil {
+			os.RemoveAll(dir)
+			return nil, fmt.Errorf("failed to fetch template: %w", err)
+		}
+		t, err := loadTemplateFiles(dir, strings.TrimSuffix(path.Base(url), ".git"))
+		if err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+		t.tempDir = dir
+		return t, nil
+	}
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		return LoadTemplateDir(source)
+	}
+	if strings.ContainsAny(source, `/\`) || strings.HasPrefix(source, ".") {
+		return nil, fmt.Errorf("template directory '%s' does not exist", source)
+	}
+	return LoadBuiltinTemplate(source)
+}
+
+// isGitURL reports whether source names a git repository rather than a path.
+func isGitURL(source string) bool {
+	for _, prefix := range []string{"https://", "http://", "ssh://", "git://", "file://", "git@"} {
+		if strings.HasPrefix(source, prefix) {
+			return true
+		}
+	}
+	url, _, _ := strings.Cut(source, "#")
+	return strings.HasSuffix(url, ".git")
+}
+
+// Close removes the files fetched for a remote template.
+func (t *Template) Close() error {
+	if t.tempDir == "" {
+		return nil
+	}
+	return os.RemoveAll(t.tempDir)
+}
+
+// LoadTemplateDir loads a template from a directory. A template without a
+// template.json is allowed; it is named after the directory and asks nothing.
+func LoadTemplateDir(dir string) (*Template, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open template: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("template '%s' is not a directory", dir)
+	}
+	return loadTemplateFiles(dir, filepath.Base(dir))
+}
+
+// loadTemplateFiles loads a template from a directory on disk. os.DirFS
+// follows symbolic links, so links that lead outside the directory are
+// rejected first; otherwise a template could copy any file the user can read,
+// such as ~/.ssh/id_rsa, into the new skill.
+func loadTemplateFiles(dir, name string) (*Template, error) {
+	root, err := filepath.Abs(dir)
+	if err == nil {
+		root, err = filepath.EvalSymlinks(root)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open template: %w", err)
+	}
+	err = filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && entry.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if entry.Type()&fs.ModeSymlink == 0 {
+			return nil
+		}
+		rel, _ := filepath.Rel(root, file)
+		target, err := filepath.EvalSymlinks(file)
+		if err != nil {
+			return fmt.Errorf("template file '%s' is a broken symbolic link", filepath.ToSlash(rel))
+		}
+		if inside, err := filepath.Rel(root, target); err != nil || inside == ".." || strings.HasPrefix(inside, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("template file '%s' is a symbolic link to '%s', outside the template", filepath.ToSlash(rel), target)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("template '%s': %w", name, err)
+	}
+	return loadTemplate(os.DirFS(root), name)
+}
+
+func loadTemplate(files fs.FS, name string) (*Template, error) {
+	t := &Template{Name: name}
+	content, err := fs.ReadFile(files, TemplateConfigFile)
+	if err == nil {
+		if err := json.Unmarshal(content, t); err != nil {
+			return nil, fmt.Errorf("failed to parse %s of template '%s': %w", TemplateConfigFile, name, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s of template '%s': %w", TemplateConfigFile, name, err)
+	}
+	t.files = files
+	if err := t.validate(); err != nil {
+		return nil, fmt.Errorf("template '%s': %w", t.Name, err)
+	}
+	return t, nil
+}
+
+func (t *Template) validate() error {
+	seen := map[string]bool{}
+	for i := range t.Prompts {
+		p := &t.Prompts[i]
+		if !promptNamePattern.MatchString(p.Name) {
+			return fmt.Errorf("prompt name '%s' must be a valid identifier", p.Name)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("prompt '%s' is defined more than once", p.Name)
+		}
+		seen[p.Name] = true
+		if p.Message == "" {
+			p.Message = p.Name + ":"
+		}
+		switch p.Type {
+		case "":
+			p.Type = PromptInput
+		case PromptInput, PromptConfirm, PromptList:
+		case PromptSelect:
+			if len(p.Options) == 0 {
+				return fmt.Errorf("select prompt '%s' has no options", p.Name)
+			}
+		default:
+			return fmt.Errorf("prompt '%s' has unknown type '%s'", p.Name, p.Type)
+		}
+		if p.Field != "" && !containsField(p.Field) {
+			return fmt.Errorf("prompt '%s' sets unknown field '%s' (expected one of: %s)", p.Name, p.Field, strings.Join(PromptFields, ", "))
+		}
+	}
+	for _, dir := range t.Directories {
+		if !fs.ValidPath(path.Clean(dir)) {
+			return fmt.Errorf("directory '%s' must be a relative path inside the skill", dir)
+		}
+	}
+	return nil
+}
+
+func containsField(field string) bool {
+	for _, f := range PromptFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckRuntime returns an error if the template cannot create a skill with
+// the given runtime type. A template that declares a runtime has scripts,
+// dependency files and instructions written for it, so it supports only that
+// runtime; a template without one supports any.
+func (t *Template) CheckRuntime(runtimeType string) error {
+	if t.Runtime == nil || t.Runtime.Type == "" || t.Runtime.Type == runtimeType {
+		return nil
+	}
+	return fmt.Errorf("template '%s' is written for the %s runtime and cannot create a skill with runtime '%s'", t.Name, t.Runtime.Type, runtimeType)
+}
+
+// DefaultAnswer returns the answer used for p when prompts are skipped.
+func (p Prompt) DefaultAnswer() (interface{}, error) {
+	switch p.Type {
+	case PromptConfirm:
+		b, _ := p.Default.(bool)
+		return b, nil
+	case PromptList:
+		switch d := p.Default.(type) {
+		case []interface{}:
+			items := []string{}
+			for _, item := range d {
+				items = append(items, fmt.Sprint(item))
+			}
+			return items, nil
+		case string:
+			return SplitList(d), nil
+		}
+		if p.Required {
+			return nil, fmt.Errorf("template prompt '%s' requires an answer", p.Name)
+		}
+		return []string{}, nil
+	}
+	s, _ := p.Default.(string)
+	if s == "" && p.Type == PromptSelect {
+		s = p.Options[0]
+	}
+	if s == "" && p.Required {
+		return nil, fmt.Errorf("template prompt '%s' requires an answer", p.Name)
+	}
+	return s, nil
+}
+
+// SplitList splits a comma-separated answer into trimmed, non-empty items.
+func SplitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ApplyAnswers stores the answers of prompts that set a manifest field in data.
+func (t *Template) ApplyAnswers(data *SkillData, answers map[string]interface{}) {
+	for _, p := range t.Prompts {
+		answer, ok := answers[p.Name]
+		if !ok || p.Field == "" {
+			continue
+		}
+		if list := answerList(p.Field, data); list != nil {
+			switch a := answer.(type) {
+			case []string:
+				*list = a
+			case string:
+				*list = SplitList(a)
+			}
+			continue
+		}
+		value := fmt.Sprint(answer)
+		switch p.Field {
+		case "description":
+			data.Description = value
+		case "author":
+			data.Author = value
+		case "license":
+			data.License = value
+		case "runtime.type":
+			data.Runtime.Type = value
+		case "runtime.version":
+			data.Runtime.Version = value
+		}
+	}
+}
+
+// answerList returns the list a field refers to, or nil if it is not a list.
+func answerList(field string, data *SkillData) *[]string {
+	if field == "keywords" {
+		return &data.Keywords
+	}
+	kind, ok := strings.CutPrefix(field, "dependencies.")
+	if !ok {
+		return nil
+	}
+	if data.Dependencies == nil {
+		data.Dependencies = &Dependencies{}
+	}
+	switch kind {
+	case "system":
+		return &data.Dependencies.System
+	case "python":
+		return &data.Dependencies.Python
+	case "npm":
+		return &data.Dependencies.Npm
+	}
+	return nil
+}
+
+// templateData is what a template's files are rendered with.
+type templateData struct {
+	SkillData
+	Title   string
+	Year    int
+	Answers map[string]interface{}
+}
+
+var templateFuncs = template.FuncMap{
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"replace": strings.ReplaceAll,
+	"join":    strings.Join,
+	"trim":    strings.TrimSpace,
+	"snake":   func(s string) string { return strings.ReplaceAll(s, "-", "_") },
+	"slug":    markdown.Slug,
+}
+
+// Render writes the template's files into skillDir, which must exist, and
+// returns the paths it created relative to skillDir. Directories end in "/".
+func (t *Template) Render(skillDir string, data SkillData, answers map[string]interface{}) ([]string, error) {
+	if answers == nil {
+		answers = map[string]interface{}{}
+	}
+	ctx := templateData{
+		SkillData: data,
+		Title:     strings.ToTitle(strings.ReplaceAll(data.Name, "-", " ")),
+		Year:      time.Now().Year(),
+		Answers:   answers,
+	}
+
+	var created []string
+	err := fs.WalkDir(t.files, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && path.Base(name) == ".git" {
+			return fs.SkipDir
+		}
+		if name == "." || name == TemplateConfigFile {
+			return nil
+		}
+
+		target, err := renderString(name, name, ctx)
+		if err != nil {
+			return err
+		}
+		target = strings.TrimSuffix(target, ".tmpl")
+		if target == "" || !fs.ValidPath(path.Clean(target)) {
+			return fmt.Errorf("template path '%s' renders outside the skill directory", name)
+		}
+		if data.Runtime.Type == RuntimeNone && (target == "scripts" || strings.HasPrefix(target, "scripts/")) {
+			// Instruction-only skills have no scripts
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		dest := filepath.Join(skillDir, filepath.FromSlash(target))
+		if entry.IsDir() {
+			return os.MkdirAll(dest, 0755)
+		}
+
+		content, err := fs.ReadFile(t.files, name)
+		if err != nil {
+			return fmt.Errorf("failed to read template file %s: %w", name, err)
+		}
+		if strings.HasSuffix(name, ".tmpl") {
+			rendered, err := renderString(name, string(content), ctx)
+			if err != nil {
+				return err
+			}
+			content = []byte(rendered)
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", target, err)
+		}
+		if err := os.WriteFile(dest, content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", target, err)
+		}
+		created = append(created, target)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dir := range t.Directories {
+		dir = path.Clean(dir)
+		if data.Runtime.Type == RuntimeNone && dir == "scripts" {
+			continue
+		}
+		dest := filepath.Join(skillDir, filepath.FromSlash(dir))
+		if _, err := os.Stat(dest); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(dest, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+		created = append(created, dir+"/")
+	}
+	return created, nil
+}
+
+// renderString executes text as a template named name.
+func renderString(name, text string, ctx templateData) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, ctx); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,113 @@
+package secrets
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// BaselineFileName is the file in a skill directory that records accepted findings.
+const BaselineFileName = ".skilzy-secrets-baseline.json"
+
+// Baseline records findings that have been reviewed and accepted, so they do
+// not block packaging. Entries hold fingerprints, never the secrets themselves.
+type Baseline struct {
+	Version     int             `json:"version"`
+	GeneratedAt time.Time       `json:"generatedAt"`
+	Findings    []BaselineEntry `json:"findings"`
+}
+
+// BaselineEntry is one accepted finding.
+type BaselineEntry struct {
+	File        string `json:"file"`
+	Line        int    `json:"line,omitempty"` // informational; matching ignores it
+	Rule        string `json:"rule"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// NewBaseline creates a baseline accepting all of findings.
+func NewBaseline(findings []Finding) *Baseline {
+	b := &Baseline{Version: 1, GeneratedAt: time.Now().UTC(), Findings: []BaselineEntry{}}
+	for _, f := range findings {
+		b.Findings = append(b.Findings, BaselineEntry{File: f.File, Line: f.Line, Rule: f.Rule, Fingerprint: f.Fingerprint()})
+	}
+	sort.Slice(b.Findings, func(i, j int) bool {
+		if b.Findings[i].File != b.Findings[j].File {
+			return b.Findings[i].File < b.Findings[j].File
+		}
+		return b.Findings[i].Line < b.Findings[j].Line
+	})
+	return b
+}
+
+// LoadBaseline reads a baseline file. A missing file yields an empty baseline.
+func LoadBaseline(path string) (*Baseline, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Baseline{Version: 1}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", BaselineFileName, err)
+	}
+	return parseBaseline(content)
+}
+
+// LoadBaselineFromArchive reads the baseline shipped at the root of a package,
+// if there is one.
+func LoadBaselineFromArchive(archivePath string) (*Baseline, error) {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open package: %w", err)
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		if stripRoot(file.Name) != BaselineFileName {
+			continue
+		}
+		content, err := readZipFile(file)
+		if err != nil {
+			return nil, err
+		}
+		return parseBaseline(content)
+	}
+	return &Baseline{Version: 1}, nil
+}
+
+func parseBaseline(content []byte) (*Baseline, error) {
+	var b Baseline
+	if err := json.Unmarshal(content, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", BaselineFileName, err)
+	}
+	return &b, nil
+}
+
+// Save writes the baseline to path.
+func (b *Baseline) Save(path string) error {
+	content, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize %s: %w", BaselineFileName, err)
+	}
+	if err := os.WriteFile(path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", BaselineFileName, err)
+	}
+	return nil
+}
+
+// Filter returns the findings not accepted by the baseline, and how many were.
+func (b *Baseline) Filter(findings []Finding) ([]Finding, int) {
+	accepted := map[string]bool{}
+	for _, e := range b.Findings {
+		accepted[e.File+"\x00"+e.Rule+"\x00"+e.Fingerprint] = true
+	}
+	var remaining []Finding
+	for _, f := range findings {
+		if !accepted[f.File+"\x00"+f.Rule+"\x00"+f.Fingerprint()] {
+			remaining = append(remaining, f)
+		}
+	}
+	return remaining, len(findings) - len(remaining)
+}
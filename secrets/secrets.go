@@ -0,0 +1,311 @@
+// Package secrets finds credentials that should not be shipped in a skill
+// package: tokens matching the formats of common cloud and AI providers,
+// private keys, dotenv files and high-entropy values assigned to
+// secret-looking names.
+package secrets
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// AllowComment marks a line whose findings are known false positives.
+// AllowNextLineComment does the same for the following line.
+const (
+	AllowComment         = "skilzy:allow-secret"
+	AllowNextLineComment = "skilzy:allow-secret-next-line"
+)
+
+// maxFileSize is the largest file scanned; larger files are assumed to be data.
+const maxFileSize = 5 * 1024 * 1024
+
+// Finding is a potential secret.
+type Finding struct {
+	File   string // slash-separated path relative to the skill directory
+	Line   int    // 1-based; 0 for findings about the file itself
+	Rule   string
+	Secret string
+}
+
+func (f Finding) String() string {
+	location := f.File
+	if f.Line > 0 {
+		location = fmt.Sprintf("%s:%d", f.File, f.Line)
+	}
+	return fmt.Sprintf("%s: [%s] %s", location, f.Rule, f.Redacted())
+}
+
+// Redacted returns the secret with all but its first characters hidden.
+func (f Finding) Redacted() string {
+	if len(f.Secret) <= 8 {
+		return f.Secret
+	}
+	return fmt.Sprintf("%s… (%d chars)", f.Secret[:4], len(f.Secret))
+}
+
+// Fingerprint identifies a finding independently of its line number, so a
+// baseline entry survives edits elsewhere in the file.
+func (f Finding) Fingerprint() string {
+	sum := sha256.Sum256([]byte(f.File + "\x00" + f.Rule + "\x00" + f.Secret))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Result is the outcome of a scan.
+type Result struct {
+	Findings []Finding
+	Files    int // number of files scanned
+}
+
+// rule is a known token format.
+type rule struct {
+	id      string
+	pattern *regexp.Regexp
+	group   int // submatch holding the secret; 0 for the whole match
+}
+
+// rules are checked in order; a match overlapping an earlier rule's match on
+// the same line is not reported twice.
+var rules = []rule{
+	{"private-key", regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----`), 0},
+	{"aws-access-key-id", regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b`), 0},
+	{"aws-secret-access-key", regexp.MustCompile(`(?i)aws.{0,20}?(?:secret|private).{0,20}?['"=:\s]\s*['"]?([A-Za-z0-9/+]{40})\b`), 1},
+	{"gcp-api-key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`), 0},
+	{"gcp-oauth-client-secret", regexp.MustCompile(`\bGOCSPX-[A-Za-z0-9_-]{28}\b`), 0},
+	{"azure-storage-key", regexp.MustCompile(`AccountKey=([A-Za-z0-9+/]{86}==)`), 1},
+	{"anthropic-api-key", regexp.MustCompile(`\bsk-ant-(?:api|admin)\d{2}-[A-Za-z0-9_-]{80,}`), 0},
+	{"openai-api-key", regexp.MustCompile(`\bsk-(?:proj-|svcacct-|admin-)?[A-Za-z0-9_-]{20,}T3BlbkFJ[A-Za-z0-9_-]{20,}|\bsk-(?:proj|svcacct|admin)-[A-Za-z0-9_-]{40,}`), 0},
+	{"huggingface-token", regexp.MustCompile(`\bhf_[A-Za-z0-9]{34,}\b`), 0},
+	{"replicate-api-token", regexp.MustCompile(`\br8_[A-Za-z0-9]{37}\b`), 0},
+	{"groq-api-key", regexp.MustCompile(`\bgsk_[A-Za-z0-9]{52}\b`), 0},
+	{"github-token", regexp.MustCompile(`\b(?:ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{36}\b|\bgithub_pat_[A-Za-z0-9_]{82}\b`), 0},
+	{"gitlab-token", regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20}\b`), 0},
+	{"slack-token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`), 0},
+	{"slack-webhook", regexp.MustCompile(`https://hooks\.slack\.com/services/T[A-Z0-9]+/B[A-Z0-9]+/[A-Za-z0-9]+`), 0},
+	{"stripe-secret-key", regexp.MustCompile(`\b[sr]k_live_[A-Za-z0-9]{24,}\b`), 0},
+	{"sendgrid-api-key", regexp.MustCompile(`\bSG\.[A-Za-z0-9_-]{22}\.[A-Za-z0-9_-]{43}\b`), 0},
+	{"npm-token", regexp.MustCompile(`\bnpm_[A-Za-z0-9]{36}\b`), 0},
+	{"pypi-token", regexp.MustCompile(`\bpypi-AgEIcHlwaS5vcmc[A-Za-z0-9_-]{50,}`), 0},
+}
+
+// genericAssignment matches a value assigned to a secret-looking name; values
+// are reported only if they look random (see looksRandom).
+var genericAssignment = regexp.MustCompile(`(?i)(?:api[_-]?key|secret|token|passw(?:or)?d|access[_-]?key|auth[_-]?key|private[_-]?key|client[_-]?secret)\w*['"]?\s*(?::=|=>|[:=])\s*['"]?([A-Za-z0-9/+_=\-]{16,})['"]?`)
+
+// ScanContent scans one file. name is used for reporting and for rules based
+// on the file name.
+func ScanContent(name string, content []byte) []Finding {
+	var findings []Finding
+	if rule := sensitiveFileRule(name); rule != "" {
+		findings = append(findings, Finding{File: name, Rule: rule, Secret: path.Base(name)})
+	}
+	if len(content) > maxFileSize || isBinary(content) {
+		return findings
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxFileSize)
+	allowNext := false
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		allowed := allowNext || strings.Contains(line, AllowComment)
+		allowNext = strings.Contains(line, AllowNextLineComment)
+		if allowed {
+			continue
+		}
+		findings = append(findings, scanLine(name, lineNum, line)...)
+	}
+	return findings
+}
+
+func scanLine(name string, lineNum int, line string) []Finding {
+	var findings []Finding
+	var spans [][2]int
+	overlaps := func(start, end int) bool {
+		for _, s := range spans {
+			if start < s[1] && s[0] < end {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, r := range rules {
+		for _, m := range r.pattern.FindAllStringSubmatchIndex(line, -1) {
+			start, end := m[2*r.group], m[2*r.group+1]
+			if start < 0 || overlaps(start, end) {
+				continue
+			}
+			spans = append(spans, [2]int{start, end})
+			findings = append(findings, Finding{File: name, Line: lineNum, Rule: r.id, Secret: line[start:end]})
+		}
+	}
+
+	for _, m := range genericAssignment.FindAllStringSubmatchIndex(line, -1) {
+		start, end := m[2], m[3]
+		value := line[start:end]
+		if overlaps(start, end) || !looksRandom(value) {
+			continue
+		}
+		spans = append(spans, [2]int{start, end})
+		findings = append(findings, Finding{File: name, Line: lineNum, Rule: "high-entropy-secret", Secret: value})
+	}
+	return findings
+}
+
+// sensitiveFileRule returns a rule ID if the file name alone indicates a secret.
+func sensitiveFileRule(name string) string {
+	base := strings.ToLower(path.Base(name))
+	switch {
+	case base == ".env" || (strings.HasPrefix(base, ".env.") && !isTemplateEnv(base)):
+		return "dotenv-file"
+	case base == "id_rsa" || base == "id_dsa" || base == "id_ecdsa" || base == "id_ed25519":
+		return "private-key-file"
+	}
+	switch path.Ext(base) {
+	case ".pem", ".key", ".p12", ".pfx", ".jks", ".keystore":
+		return "private-key-file"
+	}
+	return ""
+}
+
+// isTemplateEnv reports whether a dotenv file name is an example meant to be shared.
+func isTemplateEnv(base string) bool {
+	for _, suffix := range []string{".example", ".sample", ".template", ".dist"} {
+		if strings.HasSuffix(base, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// looksRandom reports whether a value is likely a generated credential rather
+// than an identifier, placeholder or variable reference.
+func looksRandom(value string) bool {
+	lower := strings.ToLower(value)
+	for _, placeholder := range []string{"xxxx", "your", "example", "changeme", "placeholder", "dummy", "redacted", "0000"} {
+		if strings.Contains(lower, placeholder) {
+			return false
+		}
+	}
+	hasDigit := strings.ContainsAny(value, "0123456789")
+	hasLetter := strings.IndexFunc(value, func(r rune) bool { return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' }) >= 0
+	return hasDigit && hasLetter && entropy(value) >= 3.5
+}
+
+// entropy returns the Shannon entropy of s in bits per character.
+func entropy(s string) float64 {
+	counts := map[rune]int{}
+	for _, r := range s {
+		counts[r]++
+	}
+	var h float64
+	n := float64(len(s))
+	for _, c := range counts {
+		p := float64(c) / n
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// isBinary reports whether content looks like a binary file.
+func isBinary(content []byte) bool {
+	head := content
+	if len(head) > 8000 {
+		head = head[:8000]
+	}
+	return bytes.IndexByte(head, 0) >= 0
+}
+
+// ScanDir scans every file under dir, skipping the directory skip (if any).
+func ScanDir(dir, skip string) (*Result, error) {
+	result := &Result{}
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if p == skip {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		content, err := readLimited(p, info.Size())
+		if err != nil {
+			return err
+		}
+		result.Files++
+		result.Findings = append(result.Findings, ScanContent(filepath.ToSlash(rel), content)...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+	return result, nil
+}
+
+func readLimited(p string, size int64) ([]byte, error) {
+	if size <= maxFileSize {
+		return os.ReadFile(p)
+	}
+	// Oversized files are only checked by name
+	return nil, nil
+}
+
+// ScanArchive scans the files in a skill package. Paths are reported relative
+// to the package's root folder.
+func ScanArchive(archivePath string) (*Result, error) {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open package: %w", err)
+	}
+	defer reader.Close()
+
+	result := &Result{}
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		var content []byte
+		if file.UncompressedSize64 <= maxFileSize {
+			content, err = readZipFile(file)
+			if err != nil {
+				return nil, err
+			}
+		}
+		result.Files++
+		result.Findings = append(result.Findings, ScanContent(stripRoot(file.Name), content)...)
+	}
+	return result, nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s from package: %w", file.Name, err)
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, maxFileSize+1))
+}
+
+// stripRoot removes the package's root folder from an archive entry name.
+func stripRoot(name string) string {
+	if _, rest, ok := strings.Cut(name, "/"); ok {
+		return rest
+	}
+	return name
+}
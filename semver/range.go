@@ -0,0 +1,299 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Range is a set of version constraints in npm syntax, e.g. "^1.2", "~1.2.3",
+// ">=1.0 <2", "1.x", "1.2.3 - 2.0" or "^1 || ^2". Comparators separated by
+// whitespace must all match; sets separated by "||" are alternatives.
+type Range struct {
+	raw  string
+	sets [][]comparator
+}
+
+type comparator struct {
+	op      string // one of "=", "<", "<=", ">", ">="
+	version Version
+}
+
+// partial is a version in which trailing components may be missing or wildcards.
+type partial struct {
+	major, minor, patch *uint64
+	prerelease          []string
+}
+
+// ParseRange parses a version range. An empty range or "*" matches any version.
+func ParseRange(s string) (Range, error) {
+	r := Range{raw: strings.TrimSpace(s)}
+	for _, set := range strings.Split(r.raw, "||") {
+		comparators, err := parseComparatorSet(strings.TrimSpace(set))
+		if err != nil {
+			return Range{}, fmt.Errorf("invalid range '%s': %w", r.raw, err)
+		}
+		r.sets = append(r.sets, comparators)
+	}
+	return r, nil
+}
+
+// MustParseRange is like ParseRange but panics on error.
+func MustParseRange(s string) Range {
+	r, err := ParseRange(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// String returns the range as it was written.
+func (r Range) String() string {
+	return r.raw
+}
+
+// Contains reports whether v satisfies the range. As in npm, a prerelease
+// version only matches a comparator set that names a prerelease of the same
+// MAJOR.MINOR.PATCH, so "^1.2.0" does not match "1.3.0-beta.1".
+func (r Range) Contains(v Version) bool {
+	for _, set := range r.sets {
+		if setContains(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check parses version and reports whether it satisfies the range.
+func (r Range) Check(version string) (bool, error) {
+	v, err := Parse(version)
+	if err != nil {
+		return false, err
+	}
+	return r.Contains(v), nil
+}
+
+func setContains(set []comparator, v Version) bool {
+	for _, c := range set {
+		if !c.matches(v) {
+			return false
+		}
+	}
+	if !v.IsPrerelease() {
+		return true
+	}
+	for _, c := range set {
+		cv := c.version
+		if cv.IsPrerelease() && cv.Major == v.Major && cv.Minor == v.Minor && cv.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+func (c comparator) matches(v Version) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	default:
+		return cmp == 0
+	}
+}
+
+// parseComparatorSet expands one "||"-separated alternative into primitive comparators.
+func parseComparatorSet(set string) ([]comparator, error) {
+	if set == "" {
+		return anyVersion(), nil
+	}
+
+	// Hyphen range: "1.2.3 - 2.3.4"
+	if lower, upper, ok := strings.Cut(set, " - "); ok {
+		lo, err := parsePartial(strings.TrimSpace(lower))
+		if err != nil {
+			return nil, err
+		}
+		hi, err := parsePartial(strings.TrimSpace(upper))
+		if err != nil {
+			return nil, err
+		}
+		return append(expand(">=", lo), expand("<=", hi)...), nil
+	}
+
+	var comparators []comparator
+	fields := strings.Fields(set)
+	for i := 0; i < len(fields); i++ {
+		token := fields[i]
+		op := operatorPrefix(token)
+		// Allow a space between the operator and the version, e.g. ">= 1.0"
+		if op == token && i+1 < len(fields) {
+			i++
+			token += fields[i]
+		}
+		p, err := parsePartial(strings.TrimPrefix(token, op))
+		if err != nil {
+			return nil, err
+		}
+		comparators = append(comparators, expand(op, p)...)
+	}
+	return comparators, nil
+}
+
+// operatorPrefix returns the range operator at the start of token, if any.
+func operatorPrefix(token string) string {
+	for _, op := range []string{">=", "<=", "~>", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(token, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// parsePartial parses a possibly incomplete version such as "1", "1.2", "1.x",
+// "1.2.*" or "1.2.3-beta.1". Build metadata is accepted and ignored.
+func parsePartial(s string) (partial, error) {
+	var p partial
+	input := strings.TrimPrefix(s, "v")
+	if input == "" {
+		return p, fmt.Errorf("missing version")
+	}
+	input, _, _ = strings.Cut(input, "+")
+	if core, pre, ok := strings.Cut(input, "-"); ok {
+		ids, err := parseIdentifiers(pre, true)
+		if err != nil {
+			return p, fmt.Errorf("invalid prerelease in '%s': %w", s, err)
+		}
+		p.prerelease = ids
+		input = core
+	}
+
+	parts := strings.Split(input, ".")
+	if len(parts) > 3 {
+		return p, fmt.Errorf("'%s' has more than three version components", s)
+	}
+	fields := []**uint64{&p.major, &p.minor, &p.patch}
+	wildcard := false
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			wildcard = true
+			continue
+		}
+		if wildcard {
+			return p, fmt.Errorf("'%s' has a number after a wildcard", s)
+		}
+		n, err := parseNumeric(part)
+		if err != nil {
+			return p, fmt.Errorf("invalid version '%s': %w", s, err)
+		}
+		*fields[i] = &n
+	}
+	if p.prerelease != nil && p.patch == nil {
+		return p, fmt.Errorf("'%s' has a prerelease but no patch version", s)
+	}
+	return p, nil
+}
+
+// version returns the partial with missing components set to zero.
+func (p partial) version() Version {
+	v := Version{Prerelease: p.prerelease}
+	if p.major != nil {
+		v.Major = *p.major
+	}
+	if p.minor != nil {
+		v.Minor = *p.minor
+	}
+	if p.patch != nil {
+		v.Patch = *p.patch
+	}
+	return v
+}
+
+// nextUpper returns the exclusive upper bound just past the most specific
+// component given, e.g. "1" -> "2.0.0-0" and "1.2" -> "1.3.0-0". A "-0"
+// prerelease keeps prereleases of the bound itself out of the range.
+func (p partial) nextUpper() Version {
+	v := p.version()
+	v.Prerelease = []string{"0"}
+	if p.minor == nil {
+		return Version{Major: v.Major + 1, Prerelease: v.Prerelease}
+	}
+	return Version{Major: v.Major, Minor: v.Minor + 1, Prerelease: v.Prerelease}
+}
+
+// expand desugars an operator applied to a partial version into primitive comparators.
+func expand(op string, p partial) []comparator {
+	if p.major == nil {
+		switch op {
+		case "<", ">":
+			return noVersion()
+		default:
+			return anyVersion()
+		}
+	}
+	full := p.patch != nil
+	lower := comparator{">=", p.version()}
+
+	switch op {
+	case "", "=":
+		if full {
+			return []comparator{{"=", p.version()}}
+		}
+		return []comparator{lower, {"<", p.nextUpper()}}
+	case "~", "~>":
+		if p.minor == nil {
+			return []comparator{lower, {"<", p.nextUpper()}}
+		}
+		return []comparator{lower, {"<", Version{Major: *p.major, Minor: *p.minor + 1, Prerelease: []string{"0"}}}}
+	case "^":
+		return []comparator{lower, {"<", caretUpper(p)}}
+	case ">":
+		if full {
+			return []comparator{{">", p.version()}}
+		}
+		// The bound is a release so that it does not opt in to its prereleases
+		v := p.nextUpper()
+		v.Prerelease = nil
+		return []comparator{{">=", v}}
+	case "<=":
+		if full {
+			return []comparator{{"<=", p.version()}}
+		}
+		return []comparator{{"<", p.nextUpper()}}
+	case "<":
+		v := p.version()
+		if !full {
+			v.Prerelease = []string{"0"}
+		}
+		return []comparator{{"<", v}}
+	default: // ">="
+		return []comparator{lower}
+	}
+}
+
+// caretUpper returns the exclusive upper bound of a caret range: changes that do
+// not modify the left-most non-zero component are allowed.
+func caretUpper(p partial) Version {
+	v := p.version()
+	pre := []string{"0"}
+	switch {
+	case v.Major > 0 || p.minor == nil:
+		return Version{Major: v.Major + 1, Prerelease: pre}
+	case v.Minor > 0 || p.patch == nil:
+		return Version{Minor: v.Minor + 1, Prerelease: pre}
+	default:
+		return Version{Patch: v.Patch + 1, Prerelease: pre}
+	}
+}
+
+func anyVersion() []comparator {
+	return []comparator{{">=", Version{}}}
+}
+
+func noVersion() []comparator {
+	return []comparator{{"<", Version{Prerelease: []string{"0"}}}}
+}
@@ -0,0 +1,168 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestRangeContains(t *testing.T) {
+	tests := []struct {
+		rng string
+		in  []string
+		out []string
+	}{
+		// Any version
+		{"", []string{"0.0.0", "1.2.3", "99.0.0"}, []string{"1.0.0-beta"}},
+		{"*", []string{"0.0.0", "1.2.3"}, []string{"1.0.0-beta"}},
+		{"x", []string{"0.1.0", "3.0.0"}, nil},
+
+		// Exact versions
+		{"1.2.3", []string{"1.2.3", "1.2.3+build"}, []string{"1.2.4", "1.2.2", "1.2.3-beta"}},
+		{"=1.2.3", []string{"1.2.3"}, []string{"1.2.4"}},
+		{"v1.2.3", []string{"1.2.3"}, []string{"1.2.4"}},
+		{"1.2.3-beta.2", []string{"1.2.3-beta.2"}, []string{"1.2.3-beta.3", "1.2.3"}},
+
+		// Primitive comparators
+		{">1.2.3", []string{"1.2.4", "2.0.0"}, []string{"1.2.3", "1.0.0"}},
+		{">=1.2.3", []string{"1.2.3", "1.3.0"}, []string{"1.2.2"}},
+		{"<1.2.3", []string{"1.2.2", "0.0.1"}, []string{"1.2.3", "1.2.3-beta"}},
+		{"<=1.2.3", []string{"1.2.3", "1.0.0"}, []string{"1.2.4"}},
+		{">= 1.2.3", []string{"1.2.3"}, []string{"1.2.2"}},
+		{">=1.0.0 <2.0.0", []string{"1.0.0", "1.9.9"}, []string{"2.0.0", "0.9.9"}},
+
+		// Partial versions and x-ranges
+		{"1", []string{"1.0.0", "1.9.9"}, []string{"2.0.0", "0.9.9", "2.0.0-0"}},
+		{"1.2", []string{"1.2.0", "1.2.99"}, []string{"1.3.0", "1.1.9"}},
+		{"1.x", []string{"1.0.0", "1.99.0"}, []string{"2.0.0"}},
+		{"1.2.x", []string{"1.2.0", "1.2.9"}, []string{"1.3.0"}},
+		{"1.2.*", []string{"1.2.0"}, []string{"1.3.0"}},
+		{"1.X", []string{"1.5.0"}, []string{"0.5.0"}},
+		{">1", []string{"2.0.0", "3.1.0"}, []string{"1.9.9", "1.0.0"}},
+		{">1.2", []string{"1.3.0", "2.0.0"}, []string{"1.2.9", "1.2.0"}},
+		{">=1.2", []string{"1.2.0"}, []string{"1.1.9"}},
+		{"<1.2", []string{"1.1.9"}, []string{"1.2.0", "1.2.0-beta"}},
+		{"<=1.2", []string{"1.2.9"}, []string{"1.3.0"}},
+		{">x", nil, []string{"0.0.0", "1.0.0"}},
+		{"<*", nil, []string{"0.0.0", "1.0.0"}},
+
+		// Tilde ranges
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0", "1.2.2"}},
+		{"~>1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0"}},
+		{"~1.2", []string{"1.2.0", "1.2.9"}, []string{"1.3.0"}},
+		{"~1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+		{"~0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0"}},
+		{"~1.2.3-beta.2", []string{"1.2.3-beta.2", "1.2.3-beta.4", "1.2.3", "1.2.9"}, []string{"1.2.3-beta.1", "1.2.4-beta.1", "1.3.0"}},
+
+		// Caret ranges
+		{"^1.2.3", []string{"1.2.3", "1.9.9"}, []string{"2.0.0", "1.2.2", "2.0.0-0"}},
+		{"^1.2", []string{"1.2.0", "1.9.0"}, []string{"2.0.0", "1.1.0"}},
+		{"^1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0", "0.2.2"}},
+		{"^0.2", []string{"0.2.0", "0.2.9"}, []string{"0.3.0"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4", "0.0.2"}},
+		{"^0.0", []string{"0.0.0", "0.0.9"}, []string{"0.1.0"}},
+		{"^0", []string{"0.0.0", "0.9.9"}, []string{"1.0.0"}},
+		{"^1.2.3-beta.2", []string{"1.2.3-beta.2", "1.2.3-beta.4", "1.2.3", "1.9.0"}, []string{"1.2.3-beta.1", "1.2.4-beta.1", "2.0.0"}},
+		{"^0.0.1-beta", []string{"0.0.1-beta", "0.0.1-beta.4", "0.0.1"}, []string{"0.0.2"}},
+
+		// Hyphen ranges
+		{"1.2.3 - 2.3.4", []string{"1.2.3", "2.0.0", "2.3.4"}, []string{"1.2.2", "2.3.5"}},
+		{"1.2 - 2.3.4", []string{"1.2.0", "2.3.4"}, []string{"1.1.9", "2.3.5"}},
+		{"1.2.3 - 2.3", []string{"1.2.3", "2.3.9"}, []string{"2.4.0", "2.4.0-0"}},
+		{"1.2.3 - 2", []string{"1.2.3", "2.9.9"}, []string{"3.0.0"}},
+		{"1.2.3 - *", []string{"1.2.3", "9.0.0"}, []string{"1.2.2"}},
+
+		// Alternatives
+		{"^1 || ^3", []string{"1.5.0", "3.0.0"}, []string{"2.0.0", "4.0.0"}},
+		{"<1.0.0 || >=2.0.0", []string{"0.9.0", "2.0.0"}, []string{"1.0.0", "1.5.0"}},
+		{"1.2.3 || 2.0.0-rc.1", []string{"1.2.3", "2.0.0-rc.1"}, []string{"2.0.0-rc.2", "2.0.0"}},
+		{"^1.0.0-beta || ^2.0.0", []string{"1.0.0-beta.3", "2.1.0"}, []string{"2.1.0-beta"}},
+	}
+	for _, tt := range tests {
+		r, err := ParseRange(tt.rng)
+		if err != nil {
+			t.Errorf("ParseRange(%q) returned error: %v", tt.rng, err)
+			continue
+		}
+		for _, v := range tt.in {
+			if !r.Contains(MustParse(v)) {
+				t.Errorf("%q does not contain %s, want it to", tt.rng, v)
+			}
+		}
+		for _, v := range tt.out {
+			if r.Contains(MustParse(v)) {
+				t.Errorf("%q contains %s, want it not to", tt.rng, v)
+			}
+		}
+	}
+}
+
+// TestRangePrereleaseOptIn checks npm's rule that a prerelease only matches a
+// comparator set naming a prerelease of the same MAJOR.MINOR.PATCH.
+func TestRangePrereleaseOptIn(t *testing.T) {
+	tests := []struct {
+		rng     string
+		version string
+		want    bool
+	}{
+		{"^1.2.0", "1.3.0-beta.1", false},
+		{"^1.2.0-beta.1", "1.2.0-beta.2", true},
+		{"^1.2.0-beta.1", "1.3.0-beta.1", false},
+		{">=1.0.0", "1.5.0-alpha", false},
+		{">=1.0.0-alpha", "1.0.0-beta", true},
+		{">=1.0.0-alpha", "1.0.1-beta", false},
+		{">1.2", "1.3.0-alpha", false},
+		{">1", "2.0.0-beta", false},
+		{"1.x", "1.5.0-beta", false},
+		{"*", "1.0.0-rc.1", false},
+		{"<2.0.0", "2.0.0-rc.1", false},
+		{"<2.0.0-rc.2", "2.0.0-rc.1", true},
+		{">1.0.0-alpha <1.0.0", "1.0.0-beta", true},
+		{"1.0.0 - 2.0.0-rc.2", "2.0.0-rc.1", true},
+		{"1.0.0 - 2.0.0-rc.2", "1.5.0-rc.1", false},
+	}
+	for _, tt := range tests {
+		got, err := MustParseRange(tt.rng).Check(tt.version)
+		if err != nil {
+			t.Errorf("Check(%q, %s) returned error: %v", tt.rng, tt.version, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Check(%q, %s) = %v, want %v", tt.rng, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestParseRangeErrors(t *testing.T) {
+	tests := []string{
+		"abc",
+		"1.2.3.4",
+		"^",
+		">=",
+		"1.x.3",
+		"*.2",
+		"01.2.3",
+		"1.2-beta",
+		"1.2.3-beta..1",
+		"1.2.3 - ",
+		" - 1.2.3",
+		"^1 || foo",
+		">=1.0.0 <bar",
+	}
+	for _, input := range tests {
+		if _, err := ParseRange(input); err == nil {
+			t.Errorf("ParseRange(%q) returned no error", input)
+		}
+	}
+}
+
+func TestRangeString(t *testing.T) {
+	if got := MustParseRange("  ^1.2 || ~2.0  ").String(); got != "^1.2 || ~2.0" {
+		t.Errorf("String() = %q, want %q", got, "^1.2 || ~2.0")
+	}
+}
+
+func TestRangeCheckError(t *testing.T) {
+	if _, err := MustParseRange("^1").Check("1.0"); err == nil {
+		t.Error("Check with an invalid version returned no error")
+	}
+}
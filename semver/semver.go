@@ -0,0 +1,277 @@
+// Package semver implements Semantic Versioning 2.0.0 parsing and precedence,
+// plus npm-style version ranges (caret, tilde, x-ranges, hyphen ranges and
+// comparator sets) used for skill versions, skill dependencies and runtimes.
+package semver
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed SemVer version.
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease []string
+	Build      []string
+}
+
+// Parse parses a strict MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD] version. A
+// leading "v" is accepted.
+func Parse(s string) (Version, error) {
+	var v Version
+	input := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if input == "" {
+		return v, fmt.Errorf("empty version")
+	}
+
+	if core, build, ok := strings.Cut(input, "+"); ok {
+		ids, err := parseIdentifiers(build, false)
+		if err != nil {
+			return v, fmt.Errorf("invalid build metadata in '%s': %w", s, err)
+		}
+		v.Build = ids
+		input = core
+	}
+	if core, pre, ok := strings.Cut(input, "-"); ok {
+		ids, err := parseIdentifiers(pre, true)
+		if err != nil {
+			return v, fmt.Errorf("invalid prerelease in '%s': %w", s, err)
+		}
+		v.Prerelease = ids
+		input = core
+	}
+
+	parts := strings.Split(input, ".")
+	if len(parts) != 3 {
+		return v, fmt.Errorf("'%s' is not a MAJOR.MINOR.PATCH version", s)
+	}
+	nums := make([]uint64, 3)
+	for i, part := range parts {
+		n, err := parseNumeric(part)
+		if err != nil {
+			return v, fmt.Errorf("invalid version '%s': %w", s, err)
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+	return v, nil
+}
+
+// MustParse is like Parse but panics on error.
+func MustParse(s string) Version {
+	v, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// parseNumeric parses a numeric identifier, rejecting leading zeros.
+func parseNumeric(s string) (uint64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty numeric identifier")
+	}
+	if len(s) > 1 && s[0] == '0' {
+		return 0, fmt.Errorf("numeric identifier '%s' has a leading zero", s)
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("'%s' is not a number", s)
+		}
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// parseIdentifiers splits and checks dot-separated prerelease or build identifiers.
+func parseIdentifiers(s string, prerelease bool) ([]string, error) {
+	ids := strings.Split(s, ".")
+	for _, id := range ids {
+		if id == "" {
+			return nil, fmt.Errorf("empty identifier")
+		}
+		for _, r := range id {
+			if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '-') {
+				return nil, fmt.Errorf("identifier '%s' contains invalid character %q", id, r)
+			}
+		}
+		if prerelease && isNumeric(id) && len(id) > 1 && id[0] == '0' {
+			return nil, fmt.Errorf("numeric identifier '%s' has a leading zero", id)
+		}
+	}
+	return ids, nil
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// String returns the canonical form of the version.
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if len(v.Build) > 0 {
+		s += "+" + strings.Join(v.Build, ".")
+	}
+	return s
+}
+
+// IsPrerelease reports whether the version has prerelease identifiers.
+func (v Version) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+// Compare returns -1, 0 or 1 depending on whether v has lower, equal or higher
+// precedence than o. Build metadata is ignored, as the SemVer spec requires.
+func (v Version) Compare(o Version) int {
+	if c := compareUint(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, o.Prerelease)
+}
+
+// LessThan reports whether v has lower precedence than o.
+func (v Version) LessThan(o Version) bool { return v.Compare(o) < 0 }
+
+// GreaterThan reports whether v has higher precedence than o.
+func (v Version) GreaterThan(o Version) bool { return v.Compare(o) > 0 }
+
+// Equal reports whether v and o have the same precedence.
+func (v Version) Equal(o Version) bool { return v.Compare(o) == 0 }
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePrerelease orders prerelease identifiers: a version without a
+// prerelease is greater; numeric identifiers compare numerically and are lower
+// than alphanumeric ones; a longer set wins when all shared identifiers are equal.
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		aNum, bNum := isNumeric(a[i]), isNumeric(b[i])
+		switch {
+		case aNum && bNum:
+			x, _ := strconv.ParseUint(a[i], 10, 64)
+			y, _ := strconv.ParseUint(b[i], 10, 64)
+			if c := compareUint(x, y); c != 0 {
+				return c
+			}
+		case aNum:
+			return -1
+		case bNum:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareUint(uint64(len(a)), uint64(len(b)))
+}
+
+// Compare parses and compares two version strings.
+func Compare(a, b string) (int, error) {
+	va, err := Parse(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := Parse(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+// Sort sorts versions in ascending order of precedence.
+func Sort(versions []Version) {
+	sort.SliceStable(versions, func(i, j int) bool { return versions[i].LessThan(versions[j]) })
+}
+
+// Bump returns the next version for a "major", "minor", "patch" or "prerelease"
+// release, following npm's rules: releasing a prerelease of the target version
+// drops the prerelease instead of incrementing. preid names the prerelease
+// identifier (e.g. "beta"). Build metadata is dropped.
+func (v Version) Bump(kind, preid string) (Version, error) {
+	next := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	switch kind {
+	case "major":
+		if !v.IsPrerelease() || v.Minor != 0 || v.Patch != 0 {
+			next.Major++
+		}
+		next.Minor, next.Patch = 0, 0
+	case "minor":
+		if !v.IsPrerelease() || v.Patch != 0 {
+			next.Minor++
+		}
+		next.Patch = 0
+	case "patch":
+		if !v.IsPrerelease() {
+			next.Patch++
+		}
+	case "prerelease":
+		if !v.IsPrerelease() {
+			next.Patch++
+			next.Prerelease = startPrerelease(preid)
+		} else {
+			next.Prerelease = nextPrerelease(v.Prerelease, preid)
+		}
+	default:
+		return v, fmt.Errorf("unknown bump '%s' (expected major, minor, patch or prerelease)", kind)
+	}
+	return next, nil
+}
+
+// startPrerelease returns the first prerelease identifiers for preid.
+func startPrerelease(preid string) []string {
+	if preid == "" {
+		return []string{"0"}
+	}
+	return []string{preid, "0"}
+}
+
+// nextPrerelease increments the last numeric identifier of a prerelease, or
+// restarts the sequence when switching to a different preid.
+func nextPrerelease(current []string, preid string) []string {
+	if preid != "" && current[0] != preid {
+		return startPrerelease(preid)
+	}
+	next := append([]string(nil), current...)
+	for i := len(next) - 1; i >= 0; i-- {
+		if n, err := strconv.ParseUint(next[i], 10, 64); err == nil && isNumeric(next[i]) {
+			next[i] = strconv.FormatUint(n+1, 10)
+			return next
+		}
+	}
+	return append(next, "0")
+}
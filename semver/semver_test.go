@@ -0,0 +1,172 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"v1.2.3", "1.2.3"},
+		{" 1.2.3 ", "1.2.3"},
+		{"0.0.0", "0.0.0"},
+		{"1.0.0-alpha", "1.0.0-alpha"},
+		{"1.0.0-alpha.1", "1.0.0-alpha.1"},
+		{"1.0.0-0.3.7", "1.0.0-0.3.7"},
+		{"1.0.0-x.7.z.92", "1.0.0-x.7.z.92"},
+		{"1.0.0-x-y-z.--", "1.0.0-x-y-z.--"},
+		{"1.0.0+20130313144700", "1.0.0+20130313144700"},
+		{"1.0.0-beta+exp.sha.5114f85", "1.0.0-beta+exp.sha.5114f85"},
+		{"1.0.0+21AF26D3----117B344092BD", "1.0.0+21AF26D3----117B344092BD"},
+		{"1.0.0+001", "1.0.0+001"},
+		{"18446744073709551615.0.0", "18446744073709551615.0.0"},
+	}
+	for _, tt := range tests {
+		v, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got := v.String(); got != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"v",
+		"1",
+		"1.2",
+		"1.2.3.4",
+		"01.2.3",
+		"1.02.3",
+		"1.2.03",
+		"-1.2.3",
+		"1.2.x",
+		"a.b.c",
+		"1.2.3-",
+		"1.2.3-alpha..1",
+		"1.2.3-01",
+		"1.2.3-alpha_1",
+		"1.2.3+",
+		"1.2.3+build..1",
+		"1.2.3+build!",
+		"18446744073709551616.0.0",
+	}
+	for _, input := range tests {
+		if v, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) = %s, want an error", input, v)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.0", "2.0.0", -1},
+		{"2.0.0", "2.1.0", -1},
+		{"2.1.0", "2.1.1", -1},
+		{"2.1.1", "2.1.0", 1},
+		{"1.10.0", "1.9.0", 1},
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0", "1.0.0-rc.1", 1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-alpha.beta", "1.0.0-beta", -1},
+		{"1.0.0-beta", "1.0.0-beta.2", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"1.0.0-beta.11", "1.0.0-rc.1", -1},
+		{"1.0.0-rc.1", "1.0.0-rc.1", 0},
+		{"1.0.0-1", "1.0.0-alpha", -1},
+		{"1.0.0-2", "1.0.0-10", -1},
+		{"1.0.0-Z", "1.0.0-a", -1},
+		{"1.0.0+build.1", "1.0.0+build.2", 0},
+		{"1.0.0-rc.1+build.1", "1.0.0-rc.1", 0},
+		{"0.9.9", "1.0.0-0", -1},
+	}
+	for _, tt := range tests {
+		got, err := Compare(tt.a, tt.b)
+		if err != nil {
+			t.Errorf("Compare(%q, %q) returned error: %v", tt.a, tt.b, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if reverse, _ := Compare(tt.b, tt.a); reverse != -tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.b, tt.a, reverse, -tt.want)
+		}
+	}
+}
+
+func TestCompareErrors(t *testing.T) {
+	if _, err := Compare("1.0", "1.0.0"); err == nil {
+		t.Error("Compare with an invalid first version returned no error")
+	}
+	if _, err := Compare("1.0.0", "x"); err == nil {
+		t.Error("Compare with an invalid second version returned no error")
+	}
+}
+
+func TestSort(t *testing.T) {
+	input := []string{"1.0.0", "1.0.0-rc.1", "0.1.0", "1.0.0-beta.11", "1.0.0-beta.2", "1.0.0-alpha", "2.0.0", "1.0.0-alpha.1"}
+	want := []string{"0.1.0", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "2.0.0"}
+
+	versions := make([]Version, len(input))
+	for i, s := range input {
+		versions[i] = MustParse(s)
+	}
+	Sort(versions)
+	for i, v := range versions {
+		if v.String() != want[i] {
+			t.Fatalf("Sort put %s at index %d, want %s", v, i, want[i])
+		}
+	}
+}
+
+func TestBump(t *testing.T) {
+	tests := []struct {
+		version, kind, preid string
+		want                 string
+	}{
+		{"1.2.3", "major", "", "2.0.0"},
+		{"1.2.3", "minor", "", "1.3.0"},
+		{"1.2.3", "patch", "", "1.2.4"},
+		{"1.2.3+build.5", "patch", "", "1.2.4"},
+		{"1.2.3", "prerelease", "", "1.2.4-0"},
+		{"1.2.3", "prerelease", "beta", "1.2.4-beta.0"},
+		{"1.2.4-0", "prerelease", "", "1.2.4-1"},
+		{"1.2.4-beta.0", "prerelease", "beta", "1.2.4-beta.1"},
+		{"1.2.4-beta.9", "prerelease", "", "1.2.4-beta.10"},
+		{"1.2.4-alpha.3", "prerelease", "beta", "1.2.4-beta.0"},
+		{"1.2.4-beta", "prerelease", "", "1.2.4-beta.0"},
+		{"1.2.4-beta.1.rc", "prerelease", "", "1.2.4-beta.2.rc"},
+		{"2.0.0-rc.1", "major", "", "2.0.0"},
+		{"2.1.0-rc.1", "major", "", "3.0.0"},
+		{"1.3.0-rc.1", "minor", "", "1.3.0"},
+		{"1.3.1-rc.1", "minor", "", "1.4.0"},
+		{"1.2.4-rc.1", "patch", "", "1.2.4"},
+	}
+	for _, tt := range tests {
+		got, err := MustParse(tt.version).Bump(tt.kind, tt.preid)
+		if err != nil {
+			t.Errorf("Bump(%s, %q, %q) returned error: %v", tt.version, tt.kind, tt.preid, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("Bump(%s, %q, %q) = %s, want %s", tt.version, tt.kind, tt.preid, got, tt.want)
+		}
+	}
+
+	if _, err := MustParse("1.0.0").Bump("build", ""); err == nil {
+		t.Error("Bump with an unknown kind returned no error")
+	}
+}
@@ -5,12 +5,15 @@ import (
 	"archive/zip"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -27,10 +30,27 @@ type SkilzyClient struct {
 	HTTPClient *http.Client
 }
 
+// API key scopes
+const (
+	ScopeRead    = "read"
+	ScopePublish = "publish"
+	ScopeAdmin   = "admin"
+)
+
+// APIError is returned when the registry responds with an unexpected status code
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Body)
+}
+
 // NewSkilzyClient creates a new API client
 func NewSkilzyClient(apiKey string) *SkilzyClient {
 	return &SkilzyClient{
-		BaseURL: DefaultBaseURL,
+		BaseURL: GetRegistryURL(),
 		APIKey:  apiKey,
 		HTTPClient: &http.Client{
 			Timeout: 90 * time.Second,
@@ -38,11 +58,44 @@ func NewSkilzyClient(apiKey string) *SkilzyClient {
 	}
 }
 
+// PublishOptions controls how a skill package is published
+type PublishOptions struct {
+	// Org publishes the skill under an organization namespace instead of the caller's account
+	Org string
+}
+
 // PublishSkillResponse represents the API response from publishing a skill
 type PublishSkillResponse struct {
 	Skill   string `json:"skill"`
 	Version string `json:"version"`
 	Status  string `json:"status"`
+	Owner   string `json:"owner,omitempty"`
+}
+
+// Registry-side name status values reported by a publish dry run
+const (
+	NameAvailable = "available"
+	NameOwned     = "owned"
+	NameTaken     = "taken"
+)
+
+// ErrDryRunUnsupported is returned when the registry does not offer a publish dry-run endpoint
+var ErrDryRunUnsupported = errors.New("registry does not support publish dry runs")
+
+// PolicyViolation describes a registry policy that a skill package breaks
+type PolicyViolation struct {
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+}
+
+// PublishDryRunResponse represents the registry's verdict on a package that was not published
+type PublishDryRunResponse struct {
+	Skill         string            `json:"skill"`
+	Version       string            `json:"version"`
+	NameStatus    string            `json:"nameStatus"`
+	LatestVersion string            `json:"latestVersion,omitempty"`
+	Violations    []PolicyViolation `json:"violations"`
 }
 
 // SearchResult represents a skill in search results
@@ -51,6 +104,7 @@ type SearchResult struct {
 	Author        string `json:"author"`
 	Description   string `json:"description"`
 	LatestVersion string `json:"latest_version"`
+	Deprecated    string `json:"deprecated,omitempty"`
 }
 
 // SearchResponse represents the API response from searching skills
@@ -68,6 +122,33 @@ type MySkillLatestVersion struct {
 	ReviewNotes string `json:"reviewNotes,omitempty"`
 }
 
+// Review status values for a published skill version
+const (
+	StatusPendingReview = "pending_review"
+	StatusApproved      = "approved"
+	StatusPublished     = "published"
+	StatusRejected      = "rejected"
+)
+
+// SkillVersion represents a single published version of a skill and its review state
+type SkillVersion struct {
+	Version     string     `json:"version"`
+	Status      string     `json:"status"`
+	ReviewNotes string     `json:"reviewNotes,omitempty"`
+	Yanked      bool       `json:"yanked,omitempty"`
+	Deprecated  string     `json:"deprecated,omitempty"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewedAt,omitempty"`
+}
+
+// SkillVersionsPage represents one page of a skill's versions
+type SkillVersionsPage struct {
+	Data  []SkillVersion `json:"data"`
+	Total int            `json:"total"`
+	Page  int            `json:"page"`
+	Limit int            `json:"limit"`
+}
+
 // MySkill represents a skill owned by the authenticated user
 type MySkill struct {
 	ID                    int                   `json:"id"`
@@ -77,6 +158,159 @@ type MySkill struct {
 	LatestVersion         *MySkillLatestVersion `json:"latestVersion"`
 	PublishedVersionCount int                   `json:"publishedVersionCount"`
 	TotalVersions         int                   `json:"totalVersions"`
+	Deprecated            string                `json:"deprecated,omitempty"`
+}
+
+// Organization represents an organization the authenticated user belongs to
+type Organization struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName,omitempty"`
+	Role        string `json:"role"`
+	MemberCount int    `json:"memberCount"`
+	SkillCount  int    `json:"skillCount"`
+}
+
+// APIKeyInfo describes an API key as reported by the registry
+type APIKeyInfo struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// HasScope reports whether the key grants the given scope. The admin scope implies all others.
+func (k *APIKeyInfo) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateAPIKeyRequest describes a new API key to create. Without
+// ExpiresInDays or NeverExpires, the registry applies its default expiry.
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expiresInDays,omitempty"`
+	NeverExpires  bool     `json:"neverExpires,omitempty"`
+}
+
+// CreatedAPIKey is returned once when a key is created; Key is never shown again
+type CreatedAPIKey struct {
+	APIKeyInfo
+	Key string `json:"key"`
+}
+
+// Membership represents the authenticated user's role in an organization
+type Membership struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// MeResponse represents the identity behind the authenticated API key
+type MeResponse struct {
+	Username      string       `json:"username"`
+	Email         string       `json:"email"`
+	Organizations []Membership `json:"organizations"`
+	Key           *APIKeyInfo  `json:"key"`
+}
+
+// doJSON sends an authenticated request to the registry and decodes the JSON
+// response into out. body, if non-nil, is sent as a JSON request body.
+func (c *SkilzyClient) doJSON(method, path string, query url.Values, body interface{}, out interface{}) error {
+	if c.APIKey == "" {
+		return fmt.Errorf("API key is required")
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if len(query) > 0 {
+		req.URL.RawQuery = query.Encode()
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	req.Header.Set("User-Agent", UserAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	// Send the request
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("authentication failed: invalid API key")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &APIError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	// Parse response
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetMe retrieves the account, organizations and key details for the authenticated API key
+func (c *SkilzyClient) GetMe() (*MeResponse, error) {
+	var me MeResponse
+	if err := c.doJSON("GET", "/users/me", nil, nil, &me); err != nil {
+		return nil, err
+	}
+	return &me, nil
+}
+
+// ListAPIKeys retrieves the API keys belonging to the authenticated user
+func (c *SkilzyClient) ListAPIKeys() ([]APIKeyInfo, error) {
+	var keys []APIKeyInfo
+	if err := c.doJSON("GET", "/users/me/keys", nil, nil, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// CreateAPIKey creates a new scoped API key for the authenticated user
+func (c *SkilzyClient) CreateAPIKey(request CreateAPIKeyRequest) (*CreatedAPIKey, error) {
+	var created CreatedAPIKey
+	if err := c.doJSON("POST", "/users/me/keys", nil, request, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// RevokeAPIKey permanently revokes one of the authenticated user's API keys
+func (c *SkilzyClient) RevokeAPIKey(id string) error {
+	return c.doJSON("DELETE", "/users/me/keys/"+url.PathEscape(id), nil, nil, nil)
 }
 
 // SearchSkills searches for skills in the registry
@@ -176,21 +410,188 @@ func (c *SkilzyClient) GetMySkills() ([]MySkill, error) {
 	return skills, nil
 }
 
+// GetMyOrganizations retrieves the organizations the authenticated user is a member of
+func (c *SkilzyClient) GetMyOrganizations() ([]Organization, error) {
+	var orgs []Organization
+	if err := c.doJSON("GET", "/users/me/organizations", nil, nil, &orgs); err != nil {
+		return nil, err
+	}
+	return orgs, nil
+}
+
+// GetOrgSkills retrieves all skills published under an organization namespace
+func (c *SkilzyClient) GetOrgSkills(org string) ([]MySkill, error) {
+	var skills []MySkill
+	if err := c.doJSON("GET", "/orgs/"+url.PathEscape(org)+"/skills", nil, nil, &skills); err != nil {
+		return nil, err
+	}
+	return skills, nil
+}
+
+// SkillVersionExists reports whether a version of a skill has already been published.
+// owner is an organization name, or empty for the authenticated user's own skills.
+func (c *SkilzyClient) SkillVersionExists(owner, name, version string) (bool, error) {
+	_, err := c.GetSkillVersion(owner, name, version)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// GetSkillVersion retrieves the review status of a single version of a skill.
+// owner is an organization name, or empty for the authenticated user's own skills.
+func (c *SkilzyClient) GetSkillVersion(owner, name, version string) (*SkillVersion, error) {
+	query := url.Values{}
+	if owner != "" {
+		query.Set("owner", owner)
+	}
+	var skillVersion SkillVersion
+	if err := c.doJSON("GET", "/skills/"+url.PathEscape(name)+"/versions/"+url.PathEscape(version), query, nil, &skillVersion); err != nil {
+		return nil, err
+	}
+	return &skillVersion, nil
+}
+
+// ListSkillVersions retrieves one page of a skill's versions, newest first. owner is an
+// organization name, or empty for the authenticated user's own skills; status, if
+// set, restricts the results to versions with that review status.
+func (c *SkilzyClient) ListSkillVersions(owner, name, status string, page, limit int) (*SkillVersionsPage, error) {
+	query := url.Values{}
+	if owner != "" {
+		query.Set("owner", owner)
+	}
+	if status != "" {
+		query.Set("status", status)
+	}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+
+	var versionsPage SkillVersionsPage
+	if err := c.doJSON("GET", "/skills/"+url.PathEscape(name)+"/versions", query, nil, &versionsPage); err != nil {
+		return nil, err
+	}
+	return &versionsPage, nil
+}
+
+// GetAllSkillVersions retrieves every version of a skill by following ListSkillVersions pages.
+func (c *SkilzyClient) GetAllSkillVersions(owner, name, status string) ([]SkillVersion, error) {
+	const pageSize = 100
+	var versions []SkillVersion
+	for page := 1; ; page++ {
+		versionsPage, err := c.ListSkillVersions(owner, name, status, page, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, versionsPage.Data...)
+		if len(versionsPage.Data) == 0 || len(versions) >= versionsPage.Total {
+			return versions, nil
+		}
+	}
+}
+
+// YankVersion hides a version from search and "latest" resolution while keeping it
+// downloadable by exact version. Passing undo=true restores the version.
+func (c *SkilzyClient) YankVersion(owner, name, version, reason string, undo bool) error {
+	query := url.Values{}
+	if owner != "" {
+		query.Set("owner", owner)
+	}
+	path := "/skills/" + url.PathEscape(name) + "/versions/" + url.PathEscape(version) + "/yank"
+	if undo {
+		return c.doJSON("DELETE", path, query, nil, nil)
+	}
+	return c.doJSON("POST", path, query, map[string]string{"reason": reason}, nil)
+}
+
+// DeprecateSkill marks the versions of a skill matching versionRange as deprecated
+// with the given message. An empty range deprecates every version; an empty
+// message removes the deprecation.
+func (c *SkilzyClient) DeprecateSkill(owner, name, versionRange, message string) error {
+	query := url.Values{}
+	if owner != "" {
+		query.Set("owner", owner)
+	}
+	body := map[string]string{"range": versionRange, "message": message}
+	return c.doJSON("POST", "/skills/"+url.PathEscape(name)+"/deprecate", query, body, nil)
+}
+
+// UnpublishVersion permanently removes a version. The registry only allows this
+// within a grace window after publishing.
+func (c *SkilzyClient) UnpublishVersion(owner, name, version string) error {
+	query := url.Values{}
+	if owner != "" {
+		query.Set("owner", owner)
+	}
+	return c.doJSON("DELETE", "/skills/"+url.PathEscape(name)+"/versions/"+url.PathEscape(version), query, nil, nil)
+}
+
 // PublishSkill uploads a skill package to the registry
-func (c *SkilzyClient) PublishSkill(packagePath string) (*PublishSkillResponse, error) {
+func (c *SkilzyClient) PublishSkill(packagePath string, opts PublishOptions) (*PublishSkillResponse, error) {
+	statusCode, respBody, err := c.uploadPackage("/skills/publish", packagePath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check status code
+	if statusCode != http.StatusOK && statusCode != http.StatusCreated {
+		return nil, fmt.Errorf("API error (%d): %s", statusCode, string(respBody))
+	}
+
+	// Parse response
+	var publishResp PublishSkillResponse
+	if err := json.Unmarshal(respBody, &publishResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &publishResp, nil
+}
+
+// PublishDryRun asks the registry to check a skill package without publishing it.
+// It returns ErrDryRunUnsupported if the registry has no dry-run endpoint.
+func (c *SkilzyClient) PublishDryRun(packagePath string, opts PublishOptions) (*PublishDryRunResponse, error) {
+	statusCode, respBody, err := c.uploadPackage("/skills/publish/dry-run", packagePath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check status code
+	switch statusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return nil, ErrDryRunUnsupported
+	default:
+		return nil, &APIError{StatusCode: statusCode, Body: string(respBody)}
+	}
+
+	// Parse response
+	var dryRunResp PublishDryRunResponse
+	if err := json.Unmarshal(respBody, &dryRunResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &dryRunResp, nil
+}
+
+// uploadPackage sends a skill package and its manifest as a multipart form to
+// the given endpoint, returning the response status code and body.
+func (c *SkilzyClient) uploadPackage(endpoint, packagePath string, opts PublishOptions) (int, []byte, error) {
 	if c.APIKey == "" {
-		return nil, fmt.Errorf("API key is required for publishing")
+		return 0, nil, fmt.Errorf("API key is required for publishing")
 	}
 
 	// Validate package file exists
 	if _, err := os.Stat(packagePath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("skill package not found at '%s'", packagePath)
+		return 0, nil, fmt.Errorf("skill package not found at '%s'", packagePath)
 	}
 
 	// Extract manifest from the zip file
-	manifestContent, err := extractManifestFromZip(packagePath)
+	manifestContent, err := ExtractManifestFromZip(packagePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract manifest: %w", err)
+		return 0, nil, fmt.Errorf("failed to extract manifest: %w", err)
 	}
 
 	// Prepare multipart form data
@@ -200,32 +601,39 @@ func (c *SkilzyClient) PublishSkill(packagePath string) (*PublishSkillResponse,
 	// Add the file
 	fileField, err := writer.CreateFormFile("file", filepath.Base(packagePath))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
+		return 0, nil, fmt.Errorf("failed to create form file: %w", err)
 	}
 
 	fileData, err := os.ReadFile(packagePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read package file: %w", err)
+		return 0, nil, fmt.Errorf("failed to read package file: %w", err)
 	}
 
 	if _, err := fileField.Write(fileData); err != nil {
-		return nil, fmt.Errorf("failed to write file data: %w", err)
+		return 0, nil, fmt.Errorf("failed to write file data: %w", err)
 	}
 
 	// Add the manifest
 	if err := writer.WriteField("manifest", manifestContent); err != nil {
-		return nil, fmt.Errorf("failed to add manifest field: %w", err)
+		return 0, nil, fmt.Errorf("failed to add manifest field: %w", err)
+	}
+
+	// Add the owning organization
+	if opts.Org != "" {
+		if err := writer.WriteField("org", opts.Org); err != nil {
+			return 0, nil, fmt.Errorf("failed to add org field: %w", err)
+		}
 	}
 
 	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+		return 0, nil, fmt.Errorf("failed to close multipart writer: %w", err)
 	}
 
 	// Create the request
-	url := c.BaseURL + "/skills/publish"
+	url := c.BaseURL + endpoint
 	req, err := http.NewRequest("POST", url, body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
@@ -235,32 +643,21 @@ func (c *SkilzyClient) PublishSkill(packagePath string) (*PublishSkillResponse,
 	// Send the request
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return 0, nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Read response body
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return 0, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	// Check status code
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
-	}
-
-	// Parse response
-	var publishResp PublishSkillResponse
-	if err := json.Unmarshal(respBody, &publishResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	return &publishResp, nil
+	return resp.StatusCode, respBody, nil
 }
 
-// extractManifestFromZip extracts the skill.json content from a zip file
-func extractManifestFromZip(zipPath string) (string, error) {
+// ExtractManifestFromZip extracts the skill.json content from a zip file
+func ExtractManifestFromZip(zipPath string) (string, error) {
 	reader, err := zip.OpenReader(zipPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to open zip file: %w", err)
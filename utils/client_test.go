@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateAPIKeyRequestBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		request CreateAPIKeyRequest
+		want    map[string]interface{}
+	}{
+		{
+			name:    "expires in days",
+			request: CreateAPIKeyRequest{Name: "ci", Scopes: []string{ScopePublish}, ExpiresInDays: 30},
+			want:    map[string]interface{}{"name": "ci", "scopes": []interface{}{"publish"}, "expiresInDays": float64(30)},
+		},
+		{
+			name:    "never expires",
+			request: CreateAPIKeyRequest{Name: "ci", Scopes: []string{ScopeRead}, NeverExpires: true},
+			want:    map[string]interface{}{"name": "ci", "scopes": []interface{}{"read"}, "neverExpires": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" || r.URL.Path != "/users/me/keys" {
+					t.Errorf("got %s %s, want POST /users/me/keys", r.Method, r.URL.Path)
+				}
+				content, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(content, &body); err != nil {
+					t.Errorf("request body is not JSON: %v", err)
+				}
+				w.Write([]byte(`{"id": "key_1", "key": "sk_test"}`))
+			}))
+			defer server.Close()
+
+			client := NewSkilzyClient("sk_test")
+			client.BaseURL = server.URL
+			if _, err := client.CreateAPIKey(tt.request); err != nil {
+				t.Fatalf("CreateAPIKey returned error: %v", err)
+			}
+
+			if len(body) != len(tt.want) {
+				t.Errorf("request body = %v, want %v", body, tt.want)
+			}
+			for key, want := range tt.want {
+				got, _ := json.Marshal(body[key])
+				wantJSON, _ := json.Marshal(want)
+				if string(got) != string(wantJSON) {
+					t.Errorf("request body %q = %s, want %s", key, got, wantJSON)
+				}
+			}
+		})
+	}
+}
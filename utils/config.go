@@ -6,11 +6,31 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+)
+
+// APIKeyEnvVar is the environment variable that, when set, takes precedence
+// over any API key stored on disk.
+const APIKeyEnvVar = "SKILZY_API_KEY"
+
+// RegistryEnvVar overrides the registry API URL.
+const RegistryEnvVar = "SKILZY_REGISTRY"
+
+// APIKeySource identifies where a resolved API key was loaded from.
+type APIKeySource string
+
+const (
+	APIKeySourceNone  APIKeySource = ""
+	APIKeySourceEnv   APIKeySource = "env"
+	APIKeySourceStore APIKeySource = "store"
 )
 
 // Config represents the CLI configuration
 type Config struct {
-	APIKey string `json:"api_key"`
+	APIKey           string `json:"api_key,omitempty"`
+	CredentialStore  string `json:"credential_store,omitempty"`
+	CredentialHelper string `json:"credential_helper,omitempty"`
+	Registry         string `json:"registry,omitempty"`
 }
 
 // GetConfigDir returns the path to the .skilzy config directory
@@ -31,8 +51,45 @@ func GetConfigPath() (string, error) {
 	return filepath.Join(configDir, "config.json"), nil
 }
 
-// SaveAPIKey saves the API key to the config file
-func SaveAPIKey(apiKey string) error {
+// GetRegistryURL returns the registry API URL, taken from SKILZY_REGISTRY, the
+// config file, or DefaultBaseURL in that order.
+func GetRegistryURL() string {
+	if registry := strings.TrimSpace(os.Getenv(RegistryEnvVar)); registry != "" {
+		return strings.TrimRight(registry, "/")
+	}
+	if config, err := LoadConfig(); err == nil && config.Registry != "" {
+		return strings.TrimRight(config.Registry, "/")
+	}
+	return DefaultBaseURL
+}
+
+// LoadConfig reads the config file, returning an empty config if it does not exist
+func LoadConfig() (*Config, error) {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if config file exists
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	return &config, nil
+}
+
+// SaveConfig writes the config file
+func SaveConfig(config *Config) error {
 	configDir, err := GetConfigDir()
 	if err != nil {
 		return err
@@ -43,7 +100,6 @@ func SaveAPIKey(apiKey string) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	config := Config{APIKey: apiKey}
 	data, err := json.MarshalIndent(config, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
@@ -62,27 +118,41 @@ func SaveAPIKey(apiKey string) error {
 	return nil
 }
 
-// LoadAPIKey loads the API key from the config file
-func LoadAPIKey() (string, error) {
-	configPath, err := GetConfigPath()
+// SaveAPIKey saves the API key to the configured credential store
+func SaveAPIKey(apiKey string) error {
+	store, err := GetCredentialStore()
 	if err != nil {
-		return "", err
+		return err
 	}
+	return store.Store(apiKey)
+}
 
-	// Check if config file exists
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return "", nil // No config file, return empty string
-	}
+// LoadAPIKey returns the API key to use for authenticated requests,
+// honouring the same precedence as ResolveAPIKey.
+func LoadAPIKey() (string, error) {
+	apiKey, _, err := ResolveAPIKey()
+	return apiKey, err
+}
 
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return "", fmt.Errorf("failed to read config file: %w", err)
+// ResolveAPIKey returns the active API key and where it came from. The
+// SKILZY_API_KEY environment variable takes precedence over the credential store.
+func ResolveAPIKey() (string, APIKeySource, error) {
+	if apiKey := strings.TrimSpace(os.Getenv(APIKeyEnvVar)); apiKey != "" {
+		return apiKey, APIKeySourceEnv, nil
 	}
 
-	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
-		return "", fmt.Errorf("failed to parse config file: %w", err)
+	apiKey, err := loadAPIKeyFromStore()
+	if err != nil || apiKey == "" {
+		return apiKey, APIKeySourceNone, err
 	}
+	return apiKey, APIKeySourceStore, nil
+}
 
-	return config.APIKey, nil
+// loadAPIKeyFromStore loads the API key from the configured credential store
+func loadAPIKeyFromStore() (string, error) {
+	store, err := GetCredentialStore()
+	if err != nil {
+		return "", err
+	}
+	return store.Get()
 }
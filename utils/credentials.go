@@ -0,0 +1,317 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Names of the supported credential store backends, as used in the
+// "credential_store" config field and the --store flag of 'skilzy login'.
+const (
+	CredentialStoreFile      = "file"
+	CredentialStoreEncrypted = "encrypted"
+	CredentialStoreHelper    = "helper"
+)
+
+// PassphraseEnvVar supplies the passphrase for the encrypted credential store
+// non-interactively.
+const PassphraseEnvVar = "SKILZY_CREDENTIAL_PASSPHRASE"
+
+// PassphrasePrompt is called to obtain the encrypted store passphrase when
+// SKILZY_CREDENTIAL_PASSPHRASE is not set. The cmd package installs an
+// interactive prompt; it is nil when no prompt is available.
+var PassphrasePrompt func() (string, error)
+
+// CredentialStore persists the API key used for authenticated requests.
+type CredentialStore interface {
+	// Name returns the backend name (file, encrypted or helper).
+	Name() string
+	// Describe returns a human-readable description of where the key lives.
+	Describe() string
+	// Get returns the stored API key, or an empty string if none is stored.
+	Get() (string, error)
+	// Store saves the API key, replacing any previous value.
+	Store(apiKey string) error
+	// Erase removes the stored API key.
+	Erase() error
+}
+
+// CredentialStoreNames lists the available backends in order of preference.
+func CredentialStoreNames() []string {
+	return []string{CredentialStoreHelper, CredentialStoreEncrypted, CredentialStoreFile}
+}
+
+// NewCredentialStore returns the backend with the given name. An empty name
+// selects the plaintext file store.
+func NewCredentialStore(name string, config *Config) (CredentialStore, error) {
+	switch name {
+	case "", CredentialStoreFile:
+		return &fileCredentialStore{}, nil
+	case CredentialStoreEncrypted:
+		configDir, err := GetConfigDir()
+		if err != nil {
+			return nil, err
+		}
+		return &encryptedCredentialStore{path: filepath.Join(configDir, "credentials.enc")}, nil
+	case CredentialStoreHelper:
+		if strings.TrimSpace(config.CredentialHelper) == "" {
+			return nil, fmt.Errorf("credential store 'helper' requires a credential helper command")
+		}
+		return &helperCredentialStore{command: config.CredentialHelper}, nil
+	default:
+		return nil, fmt.Errorf("unknown credential store '%s' (expected one of: %s)", name, strings.Join(CredentialStoreNames(), ", "))
+	}
+}
+
+// GetCredentialStore returns the credential store selected in the config file.
+func GetCredentialStore() (CredentialStore, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return NewCredentialStore(config.CredentialStore, config)
+}
+
+// fileCredentialStore keeps the API key in plaintext in config.json.
+type fileCredentialStore struct{}
+
+func (s *fileCredentialStore) Name() string { return CredentialStoreFile }
+
+func (s *fileCredentialStore) Describe() string {
+	configPath, _ := GetConfigPath()
+	return "plaintext config file (" + configPath + ")"
+}
+
+func (s *fileCredentialStore) Get() (string, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	return config.APIKey, nil
+}
+
+func (s *fileCredentialStore) Store(apiKey string) error {
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	config.APIKey = apiKey
+	return SaveConfig(config)
+}
+
+func (s *fileCredentialStore) Erase() error {
+	return s.Store("")
+}
+
+// encryptedFile is the on-disk format of the encrypted credential store.
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	Salt       string `json:"salt"`
+	Nonce      string `json:"nonce"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+const (
+	encryptedFileVersion = 1
+	pbkdf2Iterations     = 600000
+)
+
+// encryptedCredentialStore keeps the API key AES-256-GCM encrypted with a key
+// derived from a passphrase via PBKDF2-SHA256.
+type encryptedCredentialStore struct {
+	path string
+}
+
+func (s *encryptedCredentialStore) Name() string { return CredentialStoreEncrypted }
+
+func (s *encryptedCredentialStore) Describe() string {
+	return "encrypted file (" + s.path + ")"
+}
+
+func (s *encryptedCredentialStore) Get() (string, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return "", fmt.Errorf("failed to parse credentials file: %w", err)
+	}
+	if file.Version != encryptedFileVersion {
+		return "", fmt.Errorf("unsupported credentials file version %d", file.Version)
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(file.Salt)
+	if err != nil {
+		return "", fmt.Errorf("corrupt credentials file: %w", err)
+	}
+	nonce, err := base64.StdEncoding.DecodeString(file.Nonce)
+	if err != nil {
+		return "", fmt.Errorf("corrupt credentials file: %w", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(file.Ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("corrupt credentials file: %w", err)
+	}
+
+	gcm, err := s.cipher(salt)
+	if err != nil {
+		return "", err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return "", fmt.Errorf("corrupt credentials file: invalid nonce")
+	}
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt credentials: wrong passphrase or corrupt file")
+	}
+	return string(plaintext), nil
+}
+
+func (s *encryptedCredentialStore) Store(apiKey string) error {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+	gcm, err := s.cipher(salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	file := encryptedFile{
+		Version:    encryptedFileVersion,
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Ciphertext: base64.StdEncoding.EncodeToString(gcm.Seal(nil, nonce, []byte(apiKey), nil)),
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	return nil
+}
+
+func (s *encryptedCredentialStore) Erase() error {
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove credentials file: %w", err)
+	}
+	return nil
+}
+
+// cipher derives the AES-GCM cipher for the given salt from the passphrase.
+func (s *encryptedCredentialStore) cipher(salt []byte) (cipher.AEAD, error) {
+	passphrase := os.Getenv(PassphraseEnvVar)
+	if passphrase == "" && PassphrasePrompt != nil {
+		var err error
+		passphrase, err = PassphrasePrompt()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read passphrase: %w", err)
+		}
+	}
+	if passphrase == "" {
+		return nil, fmt.Errorf("the encrypted credential store requires a passphrase (set %s)", PassphraseEnvVar)
+	}
+
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, pbkdf2Iterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// helperCredentialStore delegates to an external credential helper that speaks
+// the git-credential protocol: the action (get, store or erase) is passed as
+// the last argument and key=value attributes are exchanged over stdin/stdout.
+type helperCredentialStore struct {
+	command string
+}
+
+func (s *helperCredentialStore) Name() string { return CredentialStoreHelper }
+
+func (s *helperCredentialStore) Describe() string {
+	return "credential helper (" + s.command + ")"
+}
+
+func (s *helperCredentialStore) Get() (string, error) {
+	output, err := s.run("get", nil)
+	if err != nil {
+		return "", err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if ok && key == "password" {
+			return value, nil
+		}
+	}
+	return "", nil
+}
+
+func (s *helperCredentialStore) Store(apiKey string) error {
+	_, err := s.run("store", map[string]string{"password": apiKey})
+	return err
+}
+
+func (s *helperCredentialStore) Erase() error {
+	_, err := s.run("erase", nil)
+	return err
+}
+
+// run invokes the helper with the given action and attributes.
+func (s *helperCredentialStore) run(action string, extra map[string]string) ([]byte, error) {
+	args := strings.Fields(s.command)
+	args = append(args, action)
+
+	host := "api.skilzy.ai"
+	if u, err := url.Parse(GetRegistryURL()); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	var input strings.Builder
+	fmt.Fprintf(&input, "protocol=https\nhost=%s\nusername=api-key\n", host)
+	for key, value := range extra {
+		fmt.Fprintf(&input, "%s=%s\n", key, value)
+	}
+	input.WriteString("\n")
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(input.String())
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("credential helper '%s %s' failed: %w", s.command, action, err)
+	}
+	return output, nil
+}
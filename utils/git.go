@@ -2,6 +2,8 @@
 package utils
 
 import (
+	"bytes"
+	"fmt"
 	"os/exec"
 	"strings"
 )
@@ -15,3 +17,126 @@ func GetGitUserName() string {
 	}
 	return strings.TrimSpace(string(output))
 }
+
+// IsGitRepo reports whether dir is inside a git work tree.
+func IsGitRepo(dir string) bool {
+	cmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	return err == nil && strings.TrimSpace(string(output)) == "true"
+}
+
+// GitCommitFiles stages the given paths and commits them with message.
+func GitCommitFiles(dir, message string, paths ...string) error {
+	if _, err := runGit(dir, append([]string{"add", "--"}, paths...)...); err != nil {
+		return err
+	}
+	_, err := runGit(dir, append([]string{"commit", "-m", message, "--"}, paths...)...)
+	return err
+}
+
+// GitTag creates an annotated tag on HEAD.
+func GitTag(dir, tag, message string) error {
+	_, err := runGit(dir, "tag", "-a", tag, "-m", message)
+	return err
+}
+
+// GitTagExists reports whether the tag exists in dir's repository.
+func GitTagExists(dir, tag string) bool {
+	_, err := runGit(dir, "rev-parse", "-q", "--verify", "refs/tags/"+tag)
+	return err == nil
+}
+
+// GitClone makes a shallow clone of url into dir, which must be empty. If ref
+// is set, that branch or tag is checked out instead of the default branch.
+func GitClone(url, ref, dir string) error {
+	args := []string{"clone", "--depth", "1", "--quiet"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	_, err := runGit("", append(args, "--", url, dir)...)
+	return err
+}
+
+// runGit runs a git command in dir and returns its trimmed output, including
+// git's stderr in the error on failure.
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Commit is a single commit returned by GitLog.
+type Commit struct {
+	Hash    string
+	Subject string
+	Body    string
+}
+
+// LatestGitTag returns the most recent tag reachable from HEAD that matches
+// pattern (a glob such as "my-skill@*"), or an empty string if there is none.
+func LatestGitTag(dir, pattern string) string {
+	tag, err := runGit(dir, "describe", "--tags", "--abbrev=0", "--match", pattern, "HEAD")
+	if err != nil {
+		return ""
+	}
+	return tag
+}
+
+// GitLog returns the commits after since (all history if empty) up to HEAD
+// that touch path, newest first.
+func GitLog(dir, since, path string) ([]Commit, error) {
+	revRange := "HEAD"
+	if since != "" {
+		revRange = since + "..HEAD"
+	}
+	output, err := runGit(dir, "log", "--format=%H%x1f%s%x1f%b%x1e", revRange, "--", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.SplitN(strings.TrimSpace(record), "\x1f", 3)
+		if len(fields) < 2 {
+			continue
+		}
+		commit := Commit{Hash: fields[0], Subject: fields[1]}
+		if len(fields) == 3 {
+			commit.Body = strings.TrimSpace(fields[2])
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// GitChangedFiles returns the files under dir that differ from ref: changes
+// committed since the branch forked from ref, uncommitted changes and
+// untracked files. Paths are relative to dir.
+func GitChangedFiles(dir, ref string) ([]string, error) {
+	base, err := runGit(dir, "merge-base", ref, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("cannot compare with '%s': %w", ref, err)
+	}
+	changed, err := runGit(dir, "diff", "--name-only", "--relative", base, "--", ".")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := runGit(dir, "ls-files", "--others", "--exclude-standard", "--", ".")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, line := range strings.Split(changed+"\n"+untracked, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,369 @@
+// Package workspace handles repositories holding several skills. A
+// skilzy-workspace.json at the repository root lists the member skills and
+// the directory packages are written to; commands run on all members, or on
+// those changed since a git ref, in dependency order.
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/skilzy/skilzy-cli/manifest"
+	"github.com/skilzy/skilzy-cli/semver"
+)
+
+// FileName is the name of the workspace file.
+const FileName = "skilzy-workspace.json"
+
+// DefaultOutputDir is where packages go if the workspace does not say.
+const DefaultOutputDir = "dist"
+
+// Workspace is a parsed skilzy-workspace.json.
+type Workspace struct {
+	// Root is the directory holding the workspace file.
+	Root string `json:"-"`
+	// Members are skill directories relative to Root; glob patterns such as
+	// "skills/*" are allowed.
+	Members []string `json:"members"`
+	// Exclude lists directories or patterns to leave out of Members.
+	Exclude []string `json:"exclude,omitempty"`
+	// OutputDir is the directory, relative to Root, that packages of all
+	// members are written to.
+	OutputDir string `json:"outputDir,omitempty"`
+}
+
+// Member is a skill in the workspace.
+type Member struct {
+	Name    string
+	Version string
+	// Author is the manifest's author, which qualified dependencies such as
+	// "acme/utils" must match to refer to this member.
+	Author string
+	// Dir is the absolute path of the skill; Path is relative to the
+	// workspace root, with forward slashes.
+	Dir  string
+	Path string
+	// Dependencies are the entries of dependencies.skills.
+	Dependencies []string
+	// Err is set if the member's skill.json could not be read; Name is then
+	// the directory name. Validating the member reports the problem.
+	Err error
+}
+
+// Load reads the workspace file at path.
+func Load(path string) (*Workspace, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var ws Workspace
+	if err := json.Unmarshal(content, &ws); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", FileName, err)
+	}
+	if len(ws.Members) == 0 {
+		return nil, fmt.Errorf("%s lists no members", FileName)
+	}
+	if ws.OutputDir == "" {
+		ws.OutputDir = DefaultOutputDir
+	}
+	ws.Root, err = filepath.Abs(filepath.Dir(path))
+	return &ws, err
+}
+
+// Find looks for a workspace file in dir and its parents. It returns nil if
+// there is none.
+func Find(dir string) (*Workspace, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		path := filepath.Join(dir, FileName)
+		if _, err := os.Stat(path); err == nil {
+			return Load(path)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// OutputPath returns the absolute path of the shared output directory.
+func (w *Workspace) OutputPath() string {
+	if filepath.IsAbs(w.OutputDir) {
+		return w.OutputDir
+	}
+	return filepath.Join(w.Root, w.OutputDir)
+}
+
+// LoadMembers returns the workspace's skills in the order they are listed,
+// with the matches of each pattern sorted by path. Pattern matches without a
+// skill.json are skipped; a literal member must be a skill. A member whose
+// skill.json cannot be parsed is returned with Err set.
+func (w *Workspace) LoadMembers() ([]*Member, error) {
+	var members []*Member
+	seenDirs := map[string]bool{}
+	byName := map[string]*Member{}
+	for _, pattern := range w.Members {
+		isGlob := strings.ContainsAny(pattern, "*?[")
+		matches, err := filepath.Glob(filepath.Join(w.Root, filepath.FromSlash(pattern)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid member pattern '%s': %w", pattern, err)
+		}
+		if len(matches) == 0 && !isGlob {
+			return nil, fmt.Errorf("member '%s' does not exist", pattern)
+		}
+		sort.Strings(matches)
+
+		for _, dir := range matches {
+			rel, _ := filepath.Rel(w.Root, dir)
+			rel = filepath.ToSlash(rel)
+			if seenDirs[dir] || w.excluded(rel) {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(dir, manifest.FileName)); err != nil {
+				if isGlob {
+					continue
+				}
+				return nil, fmt.Errorf("member '%s' has no %s", rel, manifest.FileName)
+			}
+			seenDirs[dir] = true
+
+			member := loadMember(dir, rel)
+			if other := byName[member.Name]; other != nil {
+				return nil, fmt.Errorf("members '%s' and '%s' are both named '%s'", other.Path, member.Path, member.Name)
+			}
+			byName[member.Name] = member
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+// excluded reports whether the member at rel matches an exclude entry.
+func (w *Workspace) excluded(rel string) bool {
+	for _, pattern := range w.Exclude {
+		pattern = strings.TrimSuffix(filepath.ToSlash(pattern), "/")
+		if ok, _ := filepath.Match(pattern, rel); ok || pattern == rel {
+			return true
+		}
+	}
+	return false
+}
+
+func loadMember(dir, rel string) *Member {
+	member := &Member{Name: filepath.Base(dir), Dir: dir, Path: rel}
+	content, err := os.ReadFile(filepath.Join(dir, manifest.FileName))
+	if err != nil {
+		member.Err = err
+		return member
+	}
+	var data struct {
+		Name         string `json:"name"`
+		Version      string `json:"version"`
+		Author       string `json:"author"`
+		Dependencies struct {
+			Skills []string `json:"skills"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal(content, &data); err != nil {
+		member.Err = fmt.Errorf("failed to parse %s/%s: %w", rel, manifest.FileName, err)
+		return member
+	}
+	if data.Name == "" {
+		member.Err = fmt.Errorf("%s/%s has no 'name'", rel, manifest.FileName)
+		return member
+	}
+	member.Name, member.Version, member.Author, member.Dependencies = data.Name, data.Version, data.Author, data.Dependencies.Skills
+	return member
+}
+
+// dependencyPattern matches a "[<author>/]<name>[@<range>]" entry in
+// dependencies.skills.
+var dependencyPattern = regexp.MustCompile(`^(?:([^/@\s]+)/)?([^/@\s]+)(?:@(.+))?$`)
+
+// parseDependency returns the author, skill name and version range of a
+// dependencies.skills entry. The author and range may be empty.
+func parseDependency(dep string) (author, name, versionRange string, ok bool) {
+	m := dependencyPattern.FindStringSubmatch(strings.TrimSpace(dep))
+	if m == nil {
+		return "", "", "", false
+	}
+	return m[1], m[2], m[3], true
+}
+
+// resolveDependency returns the member a dependencies.skills entry refers to
+// and the entry's version range. An entry refers to a member if it has the
+// member's name and either no author or the member's author; otherwise, as
+// with "someone-else/utils" next to a local "utils", it is a registry skill
+// and the member is nil.
+func resolveDependency(dep string, byName map[string]*Member) (*Member, string) {
+	author, name, versionRange, ok := parseDependency(dep)
+	target := byName[name]
+	if !ok || target == nil || (author != "" && !strings.EqualFold(author, target.Author)) {
+		return nil, ""
+	}
+	return target, versionRange
+}
+
+// localDependencies returns the members that m depends on.
+func localDependencies(m *Member, byName map[string]*Member) []*Member {
+	var deps []*Member
+	for _, dep := range m.Dependencies {
+		if target, _ := resolveDependency(dep, byName); target != nil && target != m {
+			deps = append(deps, target)
+		}
+	}
+	return deps
+}
+
+func indexByName(members []*Member) map[string]*Member {
+	byName := map[string]*Member{}
+	for _, m := range members {
+		byName[m.Name] = m
+	}
+	return byName
+}
+
+// Order sorts members so that every skill comes after the workspace skills
+// it depends on, keeping the listed order otherwise. It fails on a
+// dependency cycle.
+func Order(members []*Member) ([]*Member, error) {
+	byName := indexByName(members)
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := map[*Member]int{}
+	var ordered []*Member
+	var stack []string
+	var visit func(m *Member) error
+	visit = func(m *Member) error {
+		switch state[m] {
+		case done:
+			return nil
+		case visiting:
+			cycle := append(stack[indexOf(stack, m.Name):], m.Name)
+			return fmt.Errorf("dependency cycle between workspace skills: %s", strings.Join(cycle, " -> "))
+		}
+		state[m] = visiting
+		stack = append(stack, m.Name)
+		for _, dep := range localDependencies(m, byName) {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[m] = done
+		ordered = append(ordered, m)
+		return nil
+	}
+	for _, m := range members {
+		if err := visit(m); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+func indexOf(list []string, s string) int {
+	for i, item := range list {
+		if item == s {
+			return i
+		}
+	}
+	return 0
+}
+
+// Dependents returns the members of all that depend, directly or not, on
+// any of the given members.
+func Dependents(all, members []*Member) []*Member {
+	byName := indexByName(all)
+	affected := map[*Member]bool{}
+	for _, m := range members {
+		affected[m] = true
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, m := range all {
+			if affected[m] {
+				continue
+			}
+			for _, dep := range localDependencies(m, byName) {
+				if affected[dep] {
+					affected[m] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	var dependents []*Member
+	for _, m := range all {
+		if affected[m] && !contains(members, m) {
+			dependents = append(dependents, m)
+		}
+	}
+	return dependents
+}
+
+func contains(members []*Member, m *Member) bool {
+	for _, other := range members {
+		if other == m {
+			return true
+		}
+	}
+	return false
+}
+
+// Changed returns the members containing any of files, which are paths
+// relative to the workspace root such as those from utils.GitChangedFiles. A
+// member at the workspace root contains every file.
+func Changed(members []*Member, files []string) []*Member {
+	var changed []*Member
+	for _, m := range members {
+		for _, file := range files {
+			if m.Path == "." || strings.HasPrefix(filepath.ToSlash(file), m.Path+"/") {
+				changed = append(changed, m)
+				break
+			}
+		}
+	}
+	return changed
+}
+
+// CheckDependencies reports dependencies between workspace skills whose
+// version range the depended-on skill's current version does not satisfy.
+func CheckDependencies(members []*Member) []string {
+	byName := indexByName(members)
+	var problems []string
+	for _, m := range members {
+		for _, dep := range m.Dependencies {
+			target, versionRange := resolveDependency(dep, byName)
+			if target == nil || versionRange == "" {
+				continue
+			}
+			r, err := semver.ParseRange(versionRange)
+			if err != nil {
+				// Reported by the skill's own validation
+				continue
+			}
+			v, err := semver.Parse(target.Version)
+			if err != nil {
+				continue
+			}
+			if !r.Contains(v) {
+				problems = append(problems, fmt.Sprintf("%s depends on '%s', but %s in the workspace is at %s.", m.Name, dep, target.Path, target.Version))
+			}
+		}
+	}
+	return problems
+}
@@ -0,0 +1,240 @@
+package workspace
+
+import (
+	"strings"
+	"testing"
+)
+
+// names returns the names of members, joined for easy comparison.
+func names(members []*Member) string {
+	var list []string
+	for _, m := range members {
+		list = append(list, m.Name)
+	}
+	return strings.Join(list, ",")
+}
+
+func TestParseDependency(t *testing.T) {
+	tests := []struct {
+		dep                        string
+		author, name, versionRange string
+		ok                         bool
+	}{
+		{"utils", "", "utils", "", true},
+		{"utils@^2", "", "utils", "^2", true},
+		{"acme/utils", "acme", "utils", "", true},
+		{" acme/utils@>=1.0.0 <2.0.0 ", "acme", "utils", ">=1.0.0 <2.0.0", true},
+		{"a/b/c", "", "", "", false},
+		{"@^1", "", "", "", false},
+		{"", "", "", "", false},
+	}
+	for _, tt := range tests {
+		author, name, versionRange, ok := parseDependency(tt.dep)
+		if author != tt.author || name != tt.name || versionRange != tt.versionRange || ok != tt.ok {
+			t.Errorf("parseDependency(%q) = %q, %q, %q, %v; want %q, %q, %q, %v",
+				tt.dep, author, name, versionRange, ok, tt.author, tt.name, tt.versionRange, tt.ok)
+		}
+	}
+}
+
+func TestOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		members []*Member
+		want    string
+	}{
+		{
+			name: "listed order without dependencies",
+			members: []*Member{
+				{Name: "a"}, {Name: "b"}, {Name: "c"},
+			},
+			want: "a,b,c",
+		},
+		{
+			name: "dependencies come first",
+			members: []*Member{
+				{Name: "app", Dependencies: []string{"lib@^1", "utils"}},
+				{Name: "lib", Dependencies: []string{"utils"}},
+				{Name: "utils"},
+			},
+			want: "utils,lib,app",
+		},
+		{
+			name: "qualified dependency on the member's author",
+			members: []*Member{
+				{Name: "app", Dependencies: []string{"acme/utils@^2"}},
+				{Name: "utils", Author: "Acme"},
+			},
+			want: "utils,app",
+		},
+		{
+			name: "dependency on another author's skill is not local",
+			members: []*Member{
+				{Name: "app", Dependencies: []string{"someone-else/utils@^2"}},
+				{Name: "utils", Author: "acme"},
+			},
+			want: "app,utils",
+		},
+		{
+			name: "dependency on itself and on registry skills is ignored",
+			members: []*Member{
+				{Name: "app", Dependencies: []string{"app", "other/thing"}},
+				{Name: "lib"},
+			},
+			want: "app,lib",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ordered, err := Order(tt.members)
+			if err != nil {
+				t.Fatalf("Order returned error: %v", err)
+			}
+			if got := names(ordered); got != tt.want {
+				t.Errorf("Order = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderCycle(t *testing.T) {
+	tests := []struct {
+		name    string
+		members []*Member
+		want    string
+	}{
+		{
+			name: "two members",
+			members: []*Member{
+				{Name: "a", Dependencies: []string{"b"}},
+				{Name: "b", Dependencies: []string{"a@^1"}},
+			},
+			want: "dependency cycle between workspace skills: a -> b -> a",
+		},
+		{
+			name: "cycle below an acyclic member",
+			members: []*Member{
+				{Name: "app", Dependencies: []string{"x"}},
+				{Name: "x", Author: "acme", Dependencies: []string{"y"}},
+				{Name: "y", Dependencies: []string{"z"}},
+				{Name: "z", Dependencies: []string{"acme/x"}},
+			},
+			want: "dependency cycle between workspace skills: x -> y -> z -> x",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ordered, err := Order(tt.members)
+			if err == nil {
+				t.Fatalf("Order = %s, want a cycle error", names(ordered))
+			}
+			if err.Error() != tt.want {
+				t.Errorf("Order error = %q, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDependents(t *testing.T) {
+	utils := &Member{Name: "utils", Author: "acme"}
+	lib := &Member{Name: "lib", Dependencies: []string{"acme/utils@^1"}}
+	app := &Member{Name: "app", Dependencies: []string{"lib"}}
+	other := &Member{Name: "other", Dependencies: []string{"someone-else/utils"}}
+	all := []*Member{app, other, lib, utils}
+
+	tests := []struct {
+		name    string
+		members []*Member
+		want    string
+	}{
+		{"direct and indirect", []*Member{utils}, "app,lib"},
+		{"leaf", []*Member{app}, ""},
+		{"given members are left out", []*Member{utils, app}, "lib"},
+		{"middle", []*Member{lib}, "app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := names(Dependents(all, tt.members)); got != tt.want {
+				t.Errorf("Dependents = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChanged(t *testing.T) {
+	members := []*Member{
+		{Name: "a", Path: "skills/a"},
+		{Name: "ab", Path: "skills/ab"},
+		{Name: "b", Path: "tools/b"},
+	}
+	tests := []struct {
+		name    string
+		members []*Member
+		files   []string
+		want    string
+	}{
+		{"file in a member", members, []string{"skills/a/SKILL.md"}, "a"},
+		{"path prefix is not a match", members, []string{"skills/ab/README.md"}, "ab"},
+		{"several members", members, []string{"tools/b/x.py", "skills/a/scripts/run.py"}, "a,b"},
+		{"file outside the members", members, []string{"README.md", "skills/c/SKILL.md"}, ""},
+		{"no files", members, nil, ""},
+		{"member at the root", []*Member{{Name: "root", Path: "."}}, []string{"SKILL.md"}, "root"},
+		{"member at the root and below it", append([]*Member{{Name: "root", Path: "."}}, members...), []string{"tools/b/x.py"}, "root,b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := names(Changed(tt.members, tt.files)); got != tt.want {
+				t.Errorf("Changed = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckDependencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		members []*Member
+		want    []string
+	}{
+		{
+			name: "satisfied ranges",
+			members: []*Member{
+				{Name: "app", Dependencies: []string{"utils@^1.2", "acme/lib@~0.3.0"}},
+				{Name: "utils", Version: "1.4.0", Path: "skills/utils"},
+				{Name: "lib", Version: "0.3.5", Author: "acme", Path: "skills/lib"},
+			},
+		},
+		{
+			name: "unsatisfied range",
+			members: []*Member{
+				{Name: "app", Dependencies: []string{"utils@^2"}},
+				{Name: "utils", Version: "1.4.0", Path: "skills/utils"},
+			},
+			want: []string{"app depends on 'utils@^2', but skills/utils in the workspace is at 1.4.0."},
+		},
+		{
+			name: "another author's skill is not checked",
+			members: []*Member{
+				{Name: "app", Dependencies: []string{"someone-else/utils@^2"}},
+				{Name: "utils", Version: "1.4.0", Author: "acme", Path: "skills/utils"},
+			},
+		},
+		{
+			name: "no range, invalid range or invalid version",
+			members: []*Member{
+				{Name: "app", Dependencies: []string{"utils", "lib@not-a-range", "tool@^1"}},
+				{Name: "utils", Version: "1.4.0"},
+				{Name: "lib", Version: "1.0.0"},
+				{Name: "tool", Version: "latest"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheckDependencies(tt.members)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("CheckDependencies = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}